@@ -0,0 +1,185 @@
+package commands
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"brandenly.com/go/packages/discord-bot/common"
+	"brandenly.com/go/packages/discord-bot/gateway"
+)
+
+// Maximum choices in an autocomplete response
+const MaxAutocompleteChoices = 25
+
+// Suggests values for the option being typed in. At most MaxAutocompleteChoices choices are sent.
+type AutocompleteProvider func(ctx *AutocompleteContext) ([]common.ApplicationCommandOptionChoice, error)
+
+// An autocomplete request for an option of a leaf command. The embedded Context's accessors return
+// the other options filled in so far; it can't be used to respond.
+type AutocompleteContext struct {
+	*Context
+	Focused gateway.InteractionDataOption // The option being typed in
+	Partial string                        // What has been typed in so far
+}
+
+// Sets the autocomplete provider of a leaf command's option, returning the command. Panics if the
+// command has no such option.
+func (c *Command) Autocomplete(option string, provider AutocompleteProvider) *Command {
+
+	for i := range c.Options {
+		if c.Options[i].Name == option {
+			c.Options[i].Autocomplete = provider
+			return c
+		}
+	}
+
+	panic(fmt.Errorf("command %q has no option %q", c.Name, option))
+}
+
+// Responds to an autocomplete interaction for a leaf command.
+func (r *Router) autocomplete(ctx *Context) error {
+
+	focused, ok := ctx.Data.Focused()
+	if !ok {
+		return fmt.Errorf("command %q: autocomplete interaction has no focused option", strings.Join(ctx.Path, " "))
+	}
+
+	option, ok := ctx.Command.Option(focused.Name)
+	if !ok || option.Autocomplete == nil {
+		return fmt.Errorf("command %q: option %q has no autocomplete provider", strings.Join(ctx.Path, " "), focused.Name)
+	}
+
+	choices, err := option.Autocomplete(&AutocompleteContext{
+		Context: ctx,
+		Focused: focused,
+		Partial: focused.GetValueAsString(),
+	})
+	if err != nil {
+		return fmt.Errorf("command %q option %q: %w", strings.Join(ctx.Path, " "), focused.Name, err)
+	}
+
+	if len(choices) > MaxAutocompleteChoices {
+		choices = choices[:MaxAutocompleteChoices]
+	}
+
+	if choices == nil {
+		choices = []common.ApplicationCommandOptionChoice{}
+	}
+
+	return ctx.Respond(common.InteractionResponse{
+		Type: common.ApplicationCommandAutocompleteResultInteractionCallbackType,
+		Data: &common.InteractionCallbackData{Choices: &choices},
+	})
+}
+
+//// Fuzzy Matching
+
+// Returns a provider suggesting the values that best match what has been typed in.
+func Fuzzy(values ...string) AutocompleteProvider {
+
+	choices := make([]common.ApplicationCommandOptionChoice, 0, len(values))
+	for _, value := range values {
+		choices = append(choices, common.ApplicationCommandOptionChoice{Name: value, Value: value})
+	}
+
+	return FuzzyChoices(choices...)
+}
+
+// Returns a provider suggesting the choices whose names best match what has been typed in.
+func FuzzyChoices(choices ...common.ApplicationCommandOptionChoice) AutocompleteProvider {
+	return func(ctx *AutocompleteContext) ([]common.ApplicationCommandOptionChoice, error) {
+		return FuzzyMatch(ctx.Partial, choices, func(choice common.ApplicationCommandOptionChoice) string {
+			return choice.Name
+		}, MaxAutocompleteChoices), nil
+	}
+}
+
+// Returns up to limit candidates whose key matches query, best first. Matching is case-insensitive;
+// keys equal to or starting with the query rank first, then keys containing it, then keys containing
+// its characters in order, ranked by how close together they are. An empty query returns the first
+// candidates in their original order.
+func FuzzyMatch[T any](query string, candidates []T, key func(T) string, limit int) []T {
+
+	query = strings.ToLower(strings.TrimSpace(query))
+
+	type match struct {
+		candidate T
+		score     int
+	}
+
+	var matches []match
+	for _, candidate := range candidates {
+		if score, ok := fuzzyScore(query, strings.ToLower(key(candidate))); ok {
+			matches = append(matches, match{candidate, score})
+		}
+	}
+
+	slices.SortStableFunc(matches, func(a, b match) int {
+		return cmp.Compare(b.score, a.score)
+	})
+
+	if limit >= 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	results := make([]T, 0, len(matches))
+	for _, match := range matches {
+		results = append(results, match.candidate)
+	}
+
+	return results
+}
+
+// Scores how well text matches query, reporting false when the query's characters don't all appear
+// in text in order. Higher scores are better matches.
+func fuzzyScore(query string, text string) (int, bool) {
+
+	if query == "" {
+		return 0, true
+	}
+
+	switch {
+	case text == query:
+		return 4000, true
+	case strings.HasPrefix(text, query):
+		return 3000 - utf8.RuneCountInString(text), true
+	}
+
+	if index := strings.Index(text, query); index >= 0 {
+
+		score := 2000 - utf8.RuneCountInString(text[:index])
+		if previous, _ := utf8.DecodeLastRuneInString(text[:index]); index > 0 && strings.ContainsRune(" -_/.", previous) {
+			score += 500 // Matches the start of a word
+		}
+
+		return score, true
+	}
+
+	// Subsequence match, penalized by the characters skipped between query characters
+	remaining := []rune(query)
+	gaps, run := 0, 0
+
+	for _, r := range text {
+
+		if len(remaining) == 0 {
+			break
+		}
+
+		if r == remaining[0] {
+			remaining = remaining[1:]
+			gaps += run
+			run = 0
+		} else if len(remaining) < len([]rune(query)) {
+			run++
+		}
+	}
+
+	if len(remaining) > 0 {
+		return 0, false
+	}
+
+	return 1000 - gaps, true
+}
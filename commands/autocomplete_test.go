@@ -0,0 +1,98 @@
+package commands
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+
+	identity := func(s string) string { return s }
+
+	tests := []struct {
+		name       string
+		query      string
+		candidates []string
+		limit      int
+		want       []string
+	}{
+		{
+			name:       "empty query keeps order",
+			query:      "",
+			candidates: []string{"b", "a", "c"},
+			limit:      2,
+			want:       []string{"b", "a"},
+		},
+		{
+			name:       "exact before prefix before substring before subsequence",
+			query:      "ban",
+			candidates: []string{"urban", "b-a-n", "banner", "ban"},
+			limit:      -1,
+			want:       []string{"ban", "banner", "urban", "b-a-n"},
+		},
+		{
+			name:       "case insensitive",
+			query:      "KICK",
+			candidates: []string{"Kick"},
+			limit:      -1,
+			want:       []string{"Kick"},
+		},
+		{
+			name:       "no match",
+			query:      "xyz",
+			candidates: []string{"ban", "kick"},
+			limit:      -1,
+			want:       []string{},
+		},
+		{
+			name:       "shorter prefix match first",
+			query:      "mu",
+			candidates: []string{"muted", "mute"},
+			limit:      -1,
+			want:       []string{"mute", "muted"},
+		},
+		{
+			name:       "word start after non-ASCII text",
+			query:      "bar",
+			candidates: []string{"éébar", "éé bar"},
+			limit:      -1,
+			want:       []string{"éé bar", "éébar"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := FuzzyMatch(test.query, test.candidates, identity, test.limit); !slices.Equal(got, test.want) {
+				t.Errorf("FuzzyMatch(%q, %q) = %q, want %q", test.query, test.candidates, got, test.want)
+			}
+		})
+	}
+}
+
+func TestFuzzyScoreWordBoundary(t *testing.T) {
+
+	tests := []struct {
+		text string
+		word bool
+	}{
+		{"x bar", true},
+		{"x-bar", true},
+		{"xbar", false},
+		{"é bar", true},
+		{"ébar", false},
+		{"日本 bar", true},
+		{"日本bar", false},
+	}
+
+	for _, test := range tests {
+
+		score, ok := fuzzyScore("bar", test.text)
+		if !ok {
+			t.Fatalf("fuzzyScore(%q) did not match", test.text)
+		}
+
+		if word := score > 2000; word != test.word {
+			t.Errorf("fuzzyScore(%q) = %d, word start %v, want %v", test.text, score, word, test.word)
+		}
+	}
+}
@@ -0,0 +1,390 @@
+package commands
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"brandenly.com/go/packages/discord-bot/common"
+	"brandenly.com/go/packages/discord-bot/cooldown"
+)
+
+const (
+	MaxOptions           = 25   // Maximum options or subcommands per command
+	MaxNameLength        = 32   // Maximum length of command and option names
+	MaxDescriptionLength = 100  // Maximum length of command and option descriptions
+	MaxChoices           = 25   // Maximum choices per option
+	MaxOptionLength      = 6000 // Maximum length limit of a STRING option
+)
+
+// Names of CHAT_INPUT commands and options; only lowercase variants of letters are allowed.
+// External reference: https://discord.com/developers/docs/interactions/application-commands#application-command-object-application-command-naming
+var namePattern = regexp.MustCompile(`^[-_'\p{L}\p{N}\p{Devanagari}\p{Thai}]{1,32}$`)
+
+// Invoked for an application command interaction.
+type Handler func(ctx *Context) error
+
+// A node in a command tree: a top-level command, a subcommand group or a subcommand. A command
+// either has a Handler and Options, or nests Subcommands, in which case it can't be invoked itself.
+// Subcommands may nest one further level, making the middle level subcommand groups.
+type Command struct {
+	Name                     string              // Name of the command, 1-32 characters
+	Description              string              // Description of the command, 1-100 characters; unused for USER and MESSAGE commands
+	Type                     uint8               // Type of a top-level command, defaults to CHAT_INPUT
+	Options                  []Option            // Parameters of a leaf command, max of 25
+	Subcommands              []*Command          // Subcommands or subcommand groups, max of 25
+	Handler                  Handler             // Invoked when a leaf command is used
+	Limits                   *cooldown.Limits    // Leaf only; cooldowns and concurrency limits checked before Handler runs, which commands can share
+	NameLocalizations        map[string]string   // Localization dictionary for the name
+	DescriptionLocalizations map[string]string   // Localization dictionary for the description
+	DefaultMemberPermissions *common.Permissions // Top-level only; permissions members need to see the command by default
+	Contexts                 []uint              // Top-level only; interaction contexts the command can be used in
+	IntegrationTypes         []uint              // Top-level only; installation contexts the command is available in
+	Nsfw                     bool                // Top-level only; whether the command is age-restricted
+}
+
+// A parameter of a leaf command.
+// External reference: https://discord.com/developers/docs/interactions/application-commands#application-command-object-application-command-option-structure
+type Option struct {
+	Type                     int                                     // Type of the option, one of the *ApplicationCommandOptionType constants
+	Name                     string                                  // Name of the option, 1-32 characters
+	Description              string                                  // Description of the option, 1-100 characters
+	Required                 bool                                    // Whether the option must be filled in
+	Choices                  []common.ApplicationCommandOptionChoice // Fixed values to choose from, max of 25
+	ChannelTypes             []int                                   // Channel types allowed for a CHANNEL option
+	MinValue                 *float64                                // Minimum value of an INTEGER or NUMBER option
+	MaxValue                 *float64                                // Maximum value of an INTEGER or NUMBER option
+	MinLength                *int                                    // Minimum length of a STRING option, 0-6000
+	MaxLength                *int                                    // Maximum length of a STRING option, 1-6000
+	Autocomplete             AutocompleteProvider                    // Suggests values as a STRING, INTEGER or NUMBER option is typed in; can't be combined with Choices
+	NameLocalizations        map[string]string                       // Localization dictionary for the name
+	DescriptionLocalizations map[string]string                       // Localization dictionary for the description
+}
+
+// Returns the type of a top-level command, defaulting to CHAT_INPUT.
+func (c *Command) CommandType() uint8 {
+
+	if c.Type == 0 {
+		return common.ChatInputApplicationCommandType
+	}
+
+	return c.Type
+}
+
+// Returns the subcommand with the given name.
+func (c *Command) Subcommand(name string) (*Command, bool) {
+
+	for _, subcommand := range c.Subcommands {
+		if subcommand.Name == name {
+			return subcommand, true
+		}
+	}
+
+	return nil, false
+}
+
+// Returns the option with the given name.
+func (c *Command) Option(name string) (Option, bool) {
+
+	for _, option := range c.Options {
+		if option.Name == name {
+			return option, true
+		}
+	}
+
+	return Option{}, false
+}
+
+//// Validation
+
+// Reports the first way the command tree breaks Discord's rules for application commands.
+func (c *Command) Validate() error {
+
+	if c.CommandType() != common.ChatInputApplicationCommandType {
+
+		if c.Name == "" || utf8.RuneCountInString(c.Name) > MaxNameLength {
+			return fmt.Errorf("command %q: name must be 1-%d characters", c.Name, MaxNameLength)
+		}
+
+		if len(c.Options) > 0 || len(c.Subcommands) > 0 {
+			return fmt.Errorf("command %q: only CHAT_INPUT commands can have options or subcommands", c.Name)
+		}
+
+		if c.Handler == nil {
+			return fmt.Errorf("command %q: no handler", c.Name)
+		}
+
+		return nil
+	}
+
+	return c.validate(c.Name, 0)
+}
+
+func (c *Command) validate(path string, depth int) error {
+
+	if err := validateNaming(c.Name, c.Description); err != nil {
+		return fmt.Errorf("command %q: %w", path, err)
+	}
+
+	if depth > 0 && (c.DefaultMemberPermissions != nil || len(c.Contexts) > 0 || len(c.IntegrationTypes) > 0 || c.Nsfw) {
+		return fmt.Errorf("command %q: permissions, contexts, integration types and nsfw can only be set on top-level commands", path)
+	}
+
+	if len(c.Subcommands) == 0 {
+
+		if c.Handler == nil {
+			return fmt.Errorf("command %q: no handler or subcommands", path)
+		}
+
+		return validateOptions(path, c.Options)
+	}
+
+	if c.Handler != nil || len(c.Options) > 0 {
+		return fmt.Errorf("command %q: commands with subcommands can't have a handler or options", path)
+	}
+
+	if depth >= 2 {
+		return fmt.Errorf("command %q: subcommands can only be nested two levels deep", path)
+	}
+
+	if len(c.Subcommands) > MaxOptions {
+		return fmt.Errorf("command %q: more than %d subcommands", path, MaxOptions)
+	}
+
+	names := map[string]bool{}
+	for _, subcommand := range c.Subcommands {
+
+		if names[subcommand.Name] {
+			return fmt.Errorf("command %q: duplicate subcommand %q", path, subcommand.Name)
+		}
+		names[subcommand.Name] = true
+
+		if err := subcommand.validate(path+" "+subcommand.Name, depth+1); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateOptions(path string, options []Option) error {
+
+	if len(options) > MaxOptions {
+		return fmt.Errorf("command %q: more than %d options", path, MaxOptions)
+	}
+
+	names := map[string]bool{}
+	optional := false
+
+	for _, option := range options {
+
+		if err := validateNaming(option.Name, option.Description); err != nil {
+			return fmt.Errorf("command %q option %q: %w", path, option.Name, err)
+		}
+
+		if names[option.Name] {
+			return fmt.Errorf("command %q: duplicate option %q", path, option.Name)
+		}
+		names[option.Name] = true
+
+		switch option.Type {
+		case common.SubCommandApplicationCommandOptionType, common.SubCommandGroupApplicationCommandOptionType:
+			return fmt.Errorf("command %q option %q: declare subcommands with Command.Subcommands", path, option.Name)
+		case common.StringApplicationCommandOptionType, common.IntegerApplicationCommandOptionType, common.NumberApplicationCommandOptionType:
+		default:
+			if len(option.Choices) > 0 {
+				return fmt.Errorf("command %q option %q: only STRING, INTEGER and NUMBER options can have choices", path, option.Name)
+			}
+		}
+
+		if option.Type < common.StringApplicationCommandOptionType || option.Type > common.AttachmentApplicationCommandOptionType {
+			return fmt.Errorf("command %q option %q: unknown option type %d", path, option.Name, option.Type)
+		}
+
+		if option.Autocomplete != nil && len(option.Choices) > 0 {
+			return fmt.Errorf("command %q option %q: options can't have both choices and autocomplete", path, option.Name)
+		}
+
+		if option.Autocomplete != nil && option.Type != common.StringApplicationCommandOptionType && option.Type != common.IntegerApplicationCommandOptionType && option.Type != common.NumberApplicationCommandOptionType {
+			return fmt.Errorf("command %q option %q: only STRING, INTEGER and NUMBER options can autocomplete", path, option.Name)
+		}
+
+		if len(option.Choices) > MaxChoices {
+			return fmt.Errorf("command %q option %q: more than %d choices", path, option.Name, MaxChoices)
+		}
+
+		if len(option.ChannelTypes) > 0 && option.Type != common.ChannelApplicationCommandOptionType {
+			return fmt.Errorf("command %q option %q: only CHANNEL options can restrict channel types", path, option.Name)
+		}
+
+		if (option.MinValue != nil || option.MaxValue != nil) && option.Type != common.IntegerApplicationCommandOptionType && option.Type != common.NumberApplicationCommandOptionType {
+			return fmt.Errorf("command %q option %q: only INTEGER and NUMBER options can have a minimum or maximum value", path, option.Name)
+		}
+
+		if option.MinValue != nil && option.MaxValue != nil && *option.MinValue > *option.MaxValue {
+			return fmt.Errorf("command %q option %q: minimum value is greater than maximum value", path, option.Name)
+		}
+
+		if option.MinLength != nil || option.MaxLength != nil {
+
+			if option.Type != common.StringApplicationCommandOptionType {
+				return fmt.Errorf("command %q option %q: only STRING options can have a minimum or maximum length", path, option.Name)
+			}
+
+			if option.MinLength != nil && (*option.MinLength < 0 || *option.MinLength > MaxOptionLength) {
+				return fmt.Errorf("command %q option %q: minimum length must be 0-%d", path, option.Name, MaxOptionLength)
+			}
+
+			if option.MaxLength != nil && (*option.MaxLength < 1 || *option.MaxLength > MaxOptionLength) {
+				return fmt.Errorf("command %q option %q: maximum length must be 1-%d", path, option.Name, MaxOptionLength)
+			}
+
+			if option.MinLength != nil && option.MaxLength != nil && *option.MinLength > *option.MaxLength {
+				return fmt.Errorf("command %q option %q: minimum length is greater than maximum length", path, option.Name)
+			}
+		}
+
+		if option.Required && optional {
+			return fmt.Errorf("command %q option %q: required options must be listed before optional ones", path, option.Name)
+		}
+		optional = optional || !option.Required
+	}
+
+	return nil
+}
+
+func validateNaming(name string, description string) error {
+
+	if !namePattern.MatchString(name) || strings.ToLower(name) != name {
+		return fmt.Errorf("name must be 1-%d lowercase letters, numbers, dashes or underscores", MaxNameLength)
+	}
+
+	if n := utf8.RuneCountInString(description); n == 0 || n > MaxDescriptionLength {
+		return fmt.Errorf("description must be 1-%d characters", MaxDescriptionLength)
+	}
+
+	return nil
+}
+
+//// Registration
+
+// Returns the payload used to register the command tree with Discord.
+func (c *Command) ApplicationCommand() common.ApplicationCommand {
+
+	commandType := c.CommandType()
+
+	command := common.ApplicationCommand{
+		Type:                     &commandType,
+		Name:                     c.Name,
+		NameLocalizations:        localizations(c.NameLocalizations),
+		DefaultMemberPermissions: c.DefaultMemberPermissions,
+	}
+
+	if commandType == common.ChatInputApplicationCommandType {
+		command.Description = c.Description
+		command.DescriptionLocalizations = localizations(c.DescriptionLocalizations)
+
+		if options := c.applicationCommandOptions(); len(options) > 0 {
+			command.Options = &options
+		}
+	}
+
+	if c.Nsfw {
+		nsfw := true
+		command.Nsfw = &nsfw
+	}
+
+	if len(c.Contexts) > 0 {
+		contexts := c.Contexts
+		command.Contexts = &contexts
+	}
+
+	if len(c.IntegrationTypes) > 0 {
+		integrationTypes := c.IntegrationTypes
+		command.IntegrationTypes = &integrationTypes
+	}
+
+	return command
+}
+
+// Returns the command's subcommands, or its options when it is a leaf.
+func (c *Command) applicationCommandOptions() []common.ApplicationCommandOption {
+
+	var options []common.ApplicationCommandOption
+
+	for _, subcommand := range c.Subcommands {
+
+		optionType := common.SubCommandApplicationCommandOptionType
+		if len(subcommand.Subcommands) > 0 {
+			optionType = common.SubCommandGroupApplicationCommandOptionType
+		}
+
+		option := common.ApplicationCommandOption{
+			Type:                     optionType,
+			Name:                     subcommand.Name,
+			NameLocalizations:        localizations(subcommand.NameLocalizations),
+			Description:              subcommand.Description,
+			DescriptionLocalizations: localizations(subcommand.DescriptionLocalizations),
+		}
+
+		if nested := subcommand.applicationCommandOptions(); len(nested) > 0 {
+			option.Options = &nested
+		}
+
+		options = append(options, option)
+	}
+
+	for _, option := range c.Options {
+		options = append(options, option.applicationCommandOption())
+	}
+
+	return options
+}
+
+func (o Option) applicationCommandOption() common.ApplicationCommandOption {
+
+	option := common.ApplicationCommandOption{
+		Type:                     o.Type,
+		Name:                     o.Name,
+		NameLocalizations:        localizations(o.NameLocalizations),
+		Description:              o.Description,
+		DescriptionLocalizations: localizations(o.DescriptionLocalizations),
+	}
+
+	if o.Required {
+		required := true
+		option.Required = &required
+	}
+
+	if len(o.Choices) > 0 {
+		choices := o.Choices
+		option.Choices = &choices
+	}
+
+	if len(o.ChannelTypes) > 0 {
+		channelTypes := o.ChannelTypes
+		option.ChannelTypes = &channelTypes
+	}
+
+	option.MinValue = o.MinValue
+	option.MaxValue = o.MaxValue
+	option.MinLength = o.MinLength
+	option.MaxLength = o.MaxLength
+
+	if o.Autocomplete != nil {
+		autocomplete := true
+		option.Autocomplete = &autocomplete
+	}
+
+	return option
+}
+
+func localizations(values map[string]string) *map[string]string {
+
+	if len(values) == 0 {
+		return nil
+	}
+
+	return &values
+}
@@ -0,0 +1,243 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"brandenly.com/go/packages/discord-bot/common"
+	"brandenly.com/go/packages/discord-bot/components"
+	"brandenly.com/go/packages/discord-bot/cooldown"
+	"brandenly.com/go/packages/discord-bot/discord"
+	"brandenly.com/go/packages/discord-bot/gateway"
+)
+
+// The invocation of a leaf command, with typed access to its options. Accessors return the zero
+// value for options that were not filled in or hold a different type.
+type Context struct {
+	context.Context
+	App         *discord.App                               // Application that received the interaction
+	Interaction *gateway.InteractionCreate                 // The interaction being handled
+	Data        *gateway.InteractionApplicationCommandData // Decoded command data of the interaction
+	Command     *Command                                   // The invoked leaf command
+	Path        []string                                   // Names from the top-level command down to the leaf, such as ["config", "roles", "add"]
+
+	options []gateway.InteractionDataOption
+}
+
+//// Options
+
+// Returns the leaf command's filled in option with the given name.
+func (c *Context) Option(name string) (gateway.InteractionDataOption, bool) {
+
+	for _, option := range c.options {
+		if option.Name == name {
+			return option, true
+		}
+	}
+
+	return gateway.InteractionDataOption{}, false
+}
+
+// Reports whether the option was filled in.
+func (c *Context) Has(name string) bool {
+	_, ok := c.Option(name)
+	return ok
+}
+
+// Returns the value of a STRING option.
+func (c *Context) String(name string) string {
+
+	option, _ := c.Option(name)
+	value, _ := option.StringValue()
+	return value
+}
+
+// Returns the value of an INTEGER option.
+func (c *Context) Int(name string) int64 {
+
+	option, _ := c.Option(name)
+	value, _ := option.IntValue()
+	return value
+}
+
+// Returns the value of a NUMBER option.
+func (c *Context) Number(name string) float64 {
+
+	option, _ := c.Option(name)
+	value, _ := option.NumberValue()
+	return value
+}
+
+// Returns the value of a BOOLEAN option.
+func (c *Context) Bool(name string) bool {
+
+	option, _ := c.Option(name)
+	value, _ := option.BoolValue()
+	return value
+}
+
+// Returns the ID held by a USER, CHANNEL, ROLE, MENTIONABLE or ATTACHMENT option.
+func (c *Context) Snowflake(name string) common.Snowflake {
+
+	option, _ := c.Option(name)
+	value, _ := option.SnowflakeValue()
+	return value
+}
+
+// Returns the user selected in a USER or MENTIONABLE option.
+func (c *Context) User(name string) (common.User, bool) {
+	return c.Data.Resolved.User(c.Snowflake(name))
+}
+
+// Returns the guild member selected in a USER or MENTIONABLE option.
+func (c *Context) Member(name string) (common.Member, bool) {
+	return c.Data.Resolved.Member(c.Snowflake(name))
+}
+
+// Returns the channel selected in a CHANNEL option.
+func (c *Context) Channel(name string) (common.Channel, bool) {
+	return c.Data.Resolved.Channel(c.Snowflake(name))
+}
+
+// Returns the role selected in a ROLE or MENTIONABLE option.
+func (c *Context) Role(name string) (common.Role, bool) {
+	return c.Data.Resolved.Role(c.Snowflake(name))
+}
+
+// Returns the file uploaded in an ATTACHMENT option.
+func (c *Context) Attachment(name string) (common.MessageAttachment, bool) {
+	return c.Data.Resolved.Attachment(c.Snowflake(name))
+}
+
+// Returns the resolved object of a snowflake-typed option; see gateway.ResolvedData.Resolve.
+func (c *Context) Resolved(name string) any {
+
+	option, ok := c.Option(name)
+	if !ok {
+		return nil
+	}
+
+	return c.Data.Resolved.Resolve(option)
+}
+
+//// Invocation
+
+// Returns the user who invoked the command, in guilds and in DMs.
+func (c *Context) Invoker() common.User {
+
+	if c.Interaction.Member != nil && c.Interaction.Member.User != nil {
+		return *c.Interaction.Member.User
+	}
+
+	if c.Interaction.User != nil {
+		return *c.Interaction.User
+	}
+
+	return common.User{}
+}
+
+// Returns the ID of the guild the command was used in, or zero in DMs.
+func (c *Context) GuildId() common.Snowflake {
+
+	if c.Interaction.GuildId == nil {
+		return 0
+	}
+
+	return *c.Interaction.GuildId
+}
+
+// Returns the ID of the channel the command was used in.
+func (c *Context) ChannelId() common.Snowflake {
+
+	if c.Interaction.ChannelId == nil {
+		return 0
+	}
+
+	return *c.Interaction.ChannelId
+}
+
+// Returns who invoked the command and where, as counted by cooldowns.
+func (c *Context) Invocation() cooldown.Invocation {
+
+	invocation := cooldown.Invocation{UserId: c.Invoker().Id, GuildId: c.GuildId(), ChannelId: c.ChannelId()}
+	if c.Interaction.Member != nil {
+		invocation.Roles = c.Interaction.Member.Roles
+	}
+
+	return invocation
+}
+
+// Returns the user a USER command was used on.
+func (c *Context) TargetUser() (common.User, bool) {
+
+	if c.Data.TargetId == nil {
+		return common.User{}, false
+	}
+
+	return c.Data.Resolved.User(*c.Data.TargetId)
+}
+
+// Returns the message a MESSAGE command was used on.
+func (c *Context) TargetMessage() (common.Message, bool) {
+
+	if c.Data.TargetId == nil {
+		return common.Message{}, false
+	}
+
+	return c.Data.Resolved.Message(*c.Data.TargetId)
+}
+
+//// Responses
+
+// Sends a response to the interaction.
+func (c *Context) Respond(response common.InteractionResponse) error {
+	return c.App.RespondToInteraction(c.Interaction.Id, c.Interaction.Token, response)
+}
+
+// Responds with a message.
+func (c *Context) Reply(content string) error {
+	return c.Respond(common.InteractionResponse{
+		Type: common.ChannelMessageWithSourceInteractionCallbackType,
+		Data: &common.InteractionCallbackData{Content: &content},
+	})
+}
+
+// Responds with a message only the invoking user can see.
+func (c *Context) ReplyEphemeral(content string) error {
+
+	flags := common.EphemeralMessageFlag
+
+	return c.Respond(common.InteractionResponse{
+		Type: common.ChannelMessageWithSourceInteractionCallbackType,
+		Data: &common.InteractionCallbackData{Content: &content, Flags: &flags},
+	})
+}
+
+// Acknowledges the interaction, showing a loading state until the response is sent with Edit. Use
+// for handlers that may take longer than the three seconds allowed for a response.
+func (c *Context) Defer(ephemeral bool) error {
+
+	response := common.InteractionResponse{Type: common.DeferredChannelMessageWithSourceInteractionCallbackType}
+	if ephemeral {
+		flags := common.EphemeralMessageFlag
+		response.Data = &common.InteractionCallbackData{Flags: &flags}
+	}
+
+	return c.Respond(response)
+}
+
+// Responds with a modal, then passes its submission by the invoking user to handler once it arrives.
+// See components.ShowModal.
+func (c *Context) ShowModal(modal *components.ModalBuilder, handler components.ModalHandler) error {
+	return components.ShowModal(c.App, c.Interaction, modal, handler)
+}
+
+// Replaces the original response, such as a deferred one, with content.
+func (c *Context) Edit(content string) error {
+
+	if err := c.App.EditInteractionResponse(c.Interaction.Token, common.InteractionCallbackData{Content: &content}); err != nil {
+		return fmt.Errorf("command %q: %w", c.Command.Name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,430 @@
+package commands
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"brandenly.com/go/packages/discord-bot/common"
+	"brandenly.com/go/packages/discord-bot/gateway"
+)
+
+// Struct tag key declaring command options.
+const OptionTag = "discord"
+
+// Option types of struct fields, by Go type. Fields of other types map by kind: strings to STRING,
+// integers to INTEGER, floats to NUMBER and bools to BOOLEAN.
+var optionFieldTypes map[reflect.Type]int = map[reflect.Type]int{
+	reflect.TypeFor[common.User]():              common.UserApplicationCommandOptionType,
+	reflect.TypeFor[common.Member]():            common.UserApplicationCommandOptionType,
+	reflect.TypeFor[common.Channel]():           common.ChannelApplicationCommandOptionType,
+	reflect.TypeFor[common.Role]():              common.RoleApplicationCommandOptionType,
+	reflect.TypeFor[common.MessageAttachment](): common.AttachmentApplicationCommandOptionType,
+	reflect.TypeFor[common.Snowflake]():         common.MentionableApplicationCommandOptionType,
+}
+
+// Option type names accepted by the type tag key, for common.Snowflake fields.
+var optionTypeNames map[string]int = map[string]int{
+	"user":        common.UserApplicationCommandOptionType,
+	"channel":     common.ChannelApplicationCommandOptionType,
+	"role":        common.RoleApplicationCommandOptionType,
+	"mentionable": common.MentionableApplicationCommandOptionType,
+	"attachment":  common.AttachmentApplicationCommandOptionType,
+}
+
+// An option declared by a struct field.
+type optionField struct {
+	index  []int
+	option Option
+}
+
+var optionFieldCache sync.Map // reflect.Type -> []optionField
+
+// Returns the options declared by the fields of struct T. Each field tagged `discord:"..."` declares
+// an option; the tag lists the option name followed by comma separated settings:
+//
+//	required              The option must be filled in
+//	min=N, max=N          Minimum and maximum value of numeric options, or length of STRING options
+//	choices=a|b|c         Fixed values; each may be given a display name as name:value
+//	channels=a|b          Channel types allowed for channel options, such as guild_text|guild_voice
+//	type=T                Option type of a common.Snowflake field: user, channel, role, mentionable or attachment
+//	desc=...              Description of the option; must be the last setting, and may contain commas
+//
+// An empty name uses the lowercased field name, and a missing description the option name. Fields are
+// typed as strings, integers, floats, bools, common.User, common.Member, common.Channel, common.Role,
+// common.MessageAttachment or common.Snowflake, or pointers to these that are left nil when the
+// option isn't filled in.
+func OptionsOf[T any]() ([]Option, error) {
+
+	fields, err := optionFieldsOf(reflect.TypeFor[T]())
+	if err != nil {
+		return nil, err
+	}
+
+	options := make([]Option, 0, len(fields))
+	for _, field := range fields {
+		options = append(options, field.option)
+	}
+
+	return options, nil
+}
+
+// Returns a leaf command whose options are declared by the fields of T (see OptionsOf), and whose
+// handler receives them decoded. Panics if T's tags are invalid.
+func Typed[T any](name string, description string, handler func(ctx *Context, options *T) error) *Command {
+
+	options, err := OptionsOf[T]()
+	if err != nil {
+		panic(fmt.Errorf("command %q: %w", name, err))
+	}
+
+	return &Command{
+		Name:        name,
+		Description: description,
+		Options:     options,
+		Handler: func(ctx *Context) error {
+
+			var values T
+			if err := ctx.Decode(&values); err != nil {
+				return err
+			}
+
+			return handler(ctx, &values)
+		},
+	}
+}
+
+// Decodes the invoked command's options into the tagged fields of the struct dst points to. See
+// OptionsOf.
+func (c *Context) Decode(dst any) error {
+
+	target := reflect.ValueOf(dst)
+	if target.Kind() != reflect.Pointer || target.IsNil() || target.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("decode target must be a non-nil pointer to a struct, got %T", dst)
+	}
+
+	fields, err := optionFieldsOf(target.Elem().Type())
+	if err != nil {
+		return err
+	}
+
+	for _, field := range fields {
+
+		option, ok := c.Option(field.option.Name)
+		if !ok {
+			if field.option.Required {
+				return fmt.Errorf("command %q: required option %q is missing", strings.Join(c.Path, " "), field.option.Name)
+			}
+			continue
+		}
+
+		value := target.Elem().FieldByIndex(field.index)
+		if value.Kind() == reflect.Pointer {
+			value.Set(reflect.New(value.Type().Elem()))
+			value = value.Elem()
+		}
+
+		if err := c.decodeOption(option, value); err != nil {
+			return fmt.Errorf("command %q option %q: %w", strings.Join(c.Path, " "), field.option.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *Context) decodeOption(option gateway.InteractionDataOption, value reflect.Value) error {
+
+	var resolved any
+	var found bool
+
+	switch value.Type() {
+	case reflect.TypeFor[common.User]():
+		resolved, found = c.User(option.Name)
+	case reflect.TypeFor[common.Member]():
+		resolved, found = c.Member(option.Name)
+	case reflect.TypeFor[common.Channel]():
+		resolved, found = c.Channel(option.Name)
+	case reflect.TypeFor[common.Role]():
+		resolved, found = c.Role(option.Name)
+	case reflect.TypeFor[common.MessageAttachment]():
+		resolved, found = c.Attachment(option.Name)
+	case reflect.TypeFor[common.Snowflake]():
+		resolved, found = option.SnowflakeValue()
+	}
+
+	if resolved != nil {
+		if !found {
+			return fmt.Errorf("%s %s was not resolved", value.Type(), option.GetValueAsString())
+		}
+		value.Set(reflect.ValueOf(resolved))
+		return nil
+	}
+
+	switch value.Kind() {
+
+	case reflect.String:
+		s, ok := option.StringValue()
+		if !ok {
+			return fmt.Errorf("expected a string, got %T", option.Value)
+		}
+		value.SetString(s)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, ok := option.IntValue()
+		if !ok || value.OverflowInt(n) {
+			return fmt.Errorf("expected an integer that fits %s, got %v", value.Type(), option.Value)
+		}
+		value.SetInt(n)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, ok := option.IntValue()
+		if !ok || n < 0 || value.OverflowUint(uint64(n)) {
+			return fmt.Errorf("expected an integer that fits %s, got %v", value.Type(), option.Value)
+		}
+		value.SetUint(uint64(n))
+
+	case reflect.Float32, reflect.Float64:
+		n, ok := option.NumberValue()
+		if !ok {
+			return fmt.Errorf("expected a number, got %T", option.Value)
+		}
+		value.SetFloat(n)
+
+	case reflect.Bool:
+		b, ok := option.BoolValue()
+		if !ok {
+			return fmt.Errorf("expected a boolean, got %T", option.Value)
+		}
+		value.SetBool(b)
+
+	default:
+		return fmt.Errorf("unsupported field type %s", value.Type())
+	}
+
+	return nil
+}
+
+//// Tags
+
+func optionFieldsOf(t reflect.Type) ([]optionField, error) {
+
+	if cached, ok := optionFieldCache.Load(t); ok {
+		return cached.([]optionField), nil
+	}
+
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("options must be declared by a struct, got %s", t)
+	}
+
+	var fields []optionField
+	for _, field := range reflect.VisibleFields(t) {
+
+		tag, ok := field.Tag.Lookup(OptionTag)
+		if !ok || tag == "-" || !field.IsExported() {
+			continue
+		}
+
+		option, err := parseOptionTag(field, tag)
+		if err != nil {
+			return nil, fmt.Errorf("field %s.%s: %w", t.Name(), field.Name, err)
+		}
+
+		fields = append(fields, optionField{index: field.Index, option: option})
+	}
+
+	optionFieldCache.Store(t, fields)
+	return fields, nil
+}
+
+func parseOptionTag(field reflect.StructField, tag string) (Option, error) {
+
+	fieldType := field.Type
+	if fieldType.Kind() == reflect.Pointer {
+		fieldType = fieldType.Elem()
+	}
+
+	optionType, err := optionTypeOf(fieldType)
+	if err != nil {
+		return Option{}, err
+	}
+
+	name, settings, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = strings.ToLower(field.Name)
+	}
+
+	option := Option{Type: optionType, Name: name}
+
+	var min, max *float64
+	var choices []string
+
+	for settings != "" {
+
+		var setting string
+		if strings.HasPrefix(settings, "desc=") {
+			setting, settings = settings, ""
+		} else {
+			setting, settings, _ = strings.Cut(settings, ",")
+		}
+
+		key, value, _ := strings.Cut(strings.TrimSpace(setting), "=")
+
+		switch key {
+
+		case "required":
+			option.Required = true
+
+		case "desc":
+			option.Description = value
+
+		case "min", "max":
+			n, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return Option{}, fmt.Errorf("invalid %s %q", key, value)
+			}
+			if key == "min" {
+				min = &n
+			} else {
+				max = &n
+			}
+
+		case "choices":
+			choices = strings.Split(value, "|")
+
+		case "channels":
+			for _, name := range strings.Split(value, "|") {
+				channelType, ok := common.ChannelTypes[strings.ToUpper(name)]
+				if !ok {
+					return Option{}, fmt.Errorf("unknown channel type %q", name)
+				}
+				option.ChannelTypes = append(option.ChannelTypes, channelType)
+			}
+
+		case "type":
+			if fieldType != reflect.TypeFor[common.Snowflake]() {
+				return Option{}, fmt.Errorf("the option type can only be set for common.Snowflake fields")
+			}
+			t, ok := optionTypeNames[value]
+			if !ok {
+				return Option{}, fmt.Errorf("unknown option type %q", value)
+			}
+			option.Type = t
+
+		case "":
+
+		default:
+			return Option{}, fmt.Errorf("unknown tag setting %q", key)
+		}
+	}
+
+	if option.Description == "" {
+		option.Description = option.Name
+	}
+
+	switch option.Type {
+
+	case common.StringApplicationCommandOptionType:
+		option.MinLength, option.MaxLength = intBound(min), intBound(max)
+
+	case common.IntegerApplicationCommandOptionType, common.NumberApplicationCommandOptionType:
+		if isUnsigned(fieldType) { // Unsigned fields can't hold negative values
+			if min != nil && *min < 0 {
+				return Option{}, fmt.Errorf("min %v is negative for unsigned field type %s", *min, fieldType)
+			}
+			if min == nil {
+				zero := 0.0
+				min = &zero
+			}
+		}
+		option.MinValue, option.MaxValue = min, max
+
+	default:
+		if min != nil || max != nil {
+			return Option{}, fmt.Errorf("min and max only apply to integer, number and string options")
+		}
+	}
+
+	for _, choice := range choices {
+
+		name, value, ok := strings.Cut(choice, ":")
+		if !ok {
+			value = name
+		}
+
+		parsed, err := parseChoiceValue(option.Type, value)
+		if err != nil {
+			return Option{}, err
+		}
+
+		option.Choices = append(option.Choices, common.ApplicationCommandOptionChoice{Name: name, Value: parsed})
+	}
+
+	return option, nil
+}
+
+// Reports whether t is an unsigned integer type.
+func isUnsigned(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+func optionTypeOf(t reflect.Type) (int, error) {
+
+	if optionType, ok := optionFieldTypes[t]; ok {
+		return optionType, nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return common.StringApplicationCommandOptionType, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return common.IntegerApplicationCommandOptionType, nil
+	case reflect.Float32, reflect.Float64:
+		return common.NumberApplicationCommandOptionType, nil
+	case reflect.Bool:
+		return common.BooleanApplicationCommandOptionType, nil
+	}
+
+	return 0, fmt.Errorf("unsupported option field type %s", t)
+}
+
+func parseChoiceValue(optionType int, value string) (any, error) {
+
+	switch optionType {
+
+	case common.StringApplicationCommandOptionType:
+		return value, nil
+
+	case common.IntegerApplicationCommandOptionType:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer choice %q", value)
+		}
+		return n, nil
+
+	case common.NumberApplicationCommandOptionType:
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number choice %q", value)
+		}
+		return n, nil
+	}
+
+	return nil, fmt.Errorf("only STRING, INTEGER and NUMBER options can have choices")
+}
+
+func intBound(bound *float64) *int {
+
+	if bound == nil {
+		return nil
+	}
+
+	n := int(*bound)
+	return &n
+}
@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"reflect"
+	"testing"
+
+	"brandenly.com/go/packages/discord-bot/common"
+)
+
+func TestParseOptionTagBounds(t *testing.T) {
+
+	type options struct {
+		Count   int                      `discord:"count,min=1,max=10"`
+		Amount  uint8                    `discord:"amount,max=100"`
+		Signed  uint                     `discord:"signed,min=-1"`
+		Ratio   float64                  `discord:"ratio,min=0.5"`
+		Reason  string                   `discord:"reason,min=3,max=200"`
+		Flag    bool                     `discord:"flag,min=1"`
+		Target  common.User              `discord:"target,max=5"`
+		Mention common.Snowflake         `discord:"mention,type=role,min=1"`
+		File    common.MessageAttachment `discord:"file,max=1"`
+	}
+
+	bound := func(n float64) *float64 { return &n }
+	length := func(n int) *int { return &n }
+
+	tests := []struct {
+		field                string
+		minValue, maxValue   *float64
+		minLength, maxLength *int
+		err                  bool
+	}{
+		{field: "Count", minValue: bound(1), maxValue: bound(10)},
+		{field: "Amount", minValue: bound(0), maxValue: bound(100)}, // Implicit min for unsigned fields
+		{field: "Signed", err: true},
+		{field: "Ratio", minValue: bound(0.5)},
+		{field: "Reason", minLength: length(3), maxLength: length(200)},
+		{field: "Flag", err: true},
+		{field: "Target", err: true},
+		{field: "Mention", err: true},
+		{field: "File", err: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.field, func(t *testing.T) {
+
+			field, _ := reflect.TypeFor[options]().FieldByName(test.field)
+			option, err := parseOptionTag(field, field.Tag.Get(OptionTag))
+
+			if (err != nil) != test.err {
+				t.Fatalf("error = %v, want error %v", err, test.err)
+			}
+			if err != nil {
+				return
+			}
+
+			if !reflect.DeepEqual(option.MinValue, test.minValue) || !reflect.DeepEqual(option.MaxValue, test.maxValue) {
+				t.Errorf("value bounds = %v, %v, want %v, %v", option.MinValue, option.MaxValue, test.minValue, test.maxValue)
+			}
+			if !reflect.DeepEqual(option.MinLength, test.minLength) || !reflect.DeepEqual(option.MaxLength, test.maxLength) {
+				t.Errorf("length bounds = %v, %v, want %v, %v", option.MinLength, option.MaxLength, test.minLength, test.maxLength)
+			}
+		})
+	}
+}
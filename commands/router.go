@@ -0,0 +1,191 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"brandenly.com/go/packages/discord-bot/common"
+	"brandenly.com/go/packages/discord-bot/cooldown"
+	"brandenly.com/go/packages/discord-bot/discord"
+	"brandenly.com/go/packages/discord-bot/gateway"
+)
+
+// Routes application command interactions to the handlers of a command tree.
+type Router struct {
+	mu       sync.RWMutex
+	commands []*Command
+}
+
+// Returns an empty router.
+func NewRouter() *Router {
+	return &Router{}
+}
+
+// Adds top-level commands to the router, validating each first. A command replaces an earlier one
+// of the same type and name.
+func (r *Router) Add(commands ...*Command) error {
+
+	for _, command := range commands {
+		if err := command.Validate(); err != nil {
+			return err
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, command := range commands {
+
+		index := r.index(command.CommandType(), command.Name)
+		if index < 0 {
+			r.commands = append(r.commands, command)
+		} else {
+			r.commands[index] = command
+		}
+	}
+
+	return nil
+}
+
+// Returns the top-level command of the given type and name.
+func (r *Router) Command(commandType uint8, name string) (*Command, bool) {
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	index := r.index(commandType, name)
+	if index < 0 {
+		return nil, false
+	}
+
+	return r.commands[index], true
+}
+
+// Returns the registration payloads for every command in the router, in the order they were added.
+func (r *Router) ApplicationCommands() []common.ApplicationCommand {
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	commands := make([]common.ApplicationCommand, 0, len(r.commands))
+	for _, command := range r.commands {
+		commands = append(commands, command.ApplicationCommand())
+	}
+
+	return commands
+}
+
+// Registers the router's commands with Discord, globally or for a single guild when guildId is set,
+// replacing any commands registered before.
+func (r *Router) Sync(a *discord.App, guildId *common.Snowflake) error {
+
+	_, err := a.OverwriteApplicationCommands(guildId, r.ApplicationCommands())
+	return err
+}
+
+// Subscribes the router to the application's INTERACTION_CREATE events.
+func (r *Router) Register(a *discord.App) (unsubscribe func()) {
+	return discord.On(a, r.Handle)
+}
+
+// Invokes the handler of the command used in an interaction, or the autocomplete provider of the
+// option being typed in. Other interactions are ignored.
+func (r *Router) Handle(ctx context.Context, a *discord.App, interaction *gateway.InteractionCreate) error {
+
+	autocomplete := interaction.Type == gateway.InteractionType["APPLICATION_COMMAND_AUTOCOMPLETE"]
+
+	if (interaction.Type != gateway.InteractionType["APPLICATION_COMMAND"] && !autocomplete) || interaction.Data == nil {
+		return nil
+	}
+
+	var data gateway.InteractionApplicationCommandData
+	if err := json.Unmarshal(*interaction.Data, &data); err != nil {
+		return fmt.Errorf("unable to unmarshal application command data: %w", err)
+	}
+
+	commandType := uint8(data.Type)
+	if commandType == 0 {
+		commandType = common.ChatInputApplicationCommandType
+	}
+
+	command, ok := r.Command(commandType, data.Name)
+	if !ok {
+		return fmt.Errorf("no handler for command %q", data.Name)
+	}
+
+	leaf, path, options, err := walk(command, data.Options)
+	if err != nil {
+		return err
+	}
+
+	commandCtx := &Context{
+		Context:     ctx,
+		App:         a,
+		Interaction: interaction,
+		Data:        &data,
+		Command:     leaf,
+		Path:        path,
+		options:     options,
+	}
+
+	if autocomplete {
+		return r.autocomplete(commandCtx)
+	}
+
+	release, err := leaf.Limits.Acquire(commandCtx.Invocation())
+	if err != nil {
+		var limited *cooldown.Error
+		if errors.As(err, &limited) {
+			return commandCtx.ReplyEphemeral(limited.Message)
+		}
+		return err
+	}
+	defer release()
+
+	return leaf.Handler(commandCtx)
+}
+
+// Follows subcommand and subcommand group options from a top-level command down to the invoked leaf
+// command, returning it along with the names on the way and the leaf's options.
+func walk(command *Command, options *[]gateway.InteractionDataOption) (*Command, []string, []gateway.InteractionDataOption, error) {
+
+	path := []string{command.Name}
+
+	var values []gateway.InteractionDataOption
+	if options != nil {
+		values = *options
+	}
+
+	for len(values) > 0 && values[0].IsSubcommand() {
+
+		subcommand, ok := command.Subcommand(values[0].Name)
+		if !ok {
+			return nil, path, nil, fmt.Errorf("no handler for command %q", strings.Join(append(path, values[0].Name), " "))
+		}
+
+		command = subcommand
+		path = append(path, subcommand.Name)
+		values = values[0].NestedOptions()
+	}
+
+	if len(command.Subcommands) > 0 {
+		return nil, path, nil, fmt.Errorf("command %q was invoked without a subcommand", strings.Join(path, " "))
+	}
+
+	return command, path, values, nil
+}
+
+func (r *Router) index(commandType uint8, name string) int {
+
+	for i, command := range r.commands {
+		if command.CommandType() == commandType && command.Name == name {
+			return i
+		}
+	}
+
+	return -1
+}
@@ -8,9 +8,9 @@ type Activity struct {
 	Url   *string `json:"url,omitempty"`   // Stream URL, is validated when type is 1
 	Emoji *Emoji  `json:"emoji,omitempty"` // Emoji used for a custom status
 	// Bots cannot modify the fields below
-	CreatedAt     int64   `json:"created_at"`               // Unix timestamp (in milliseconds) of when the activity was added to the user's session
-	ApplicationId *uint64 `json:"application_id,omitempty"` // Application ID for the game
-	Details       *string `json:"details,omitempty"`        // What the player is currently doing
-	Instance      *bool   `json:"instance,omitempty"`       // Whether or not the activity is an instanced game session
-	Flags         *int    `json:"flags,omitempty"`          // Activity flags ORd together, describes what the payload includes
+	CreatedAt     int64      `json:"created_at"`               // Unix timestamp (in milliseconds) of when the activity was added to the user's session
+	ApplicationId *Snowflake `json:"application_id,omitempty"` // Application ID for the game
+	Details       *string    `json:"details,omitempty"`        // What the player is currently doing
+	Instance      *bool      `json:"instance,omitempty"`       // Whether or not the activity is an instanced game session
+	Flags         *int       `json:"flags,omitempty"`          // Activity flags ORd together, describes what the payload includes
 }
@@ -20,22 +20,22 @@ const ( // Application Command Handler Types
 
 // External reference: https://discord.com/developers/docs/interactions/application-commands#application-command-object-application-command-structure
 type ApplicationCommand struct {
-	Id                       string                      `json:"id"`                                  // Unique ID of command
+	Id                       Snowflake                   `json:"id,omitempty"`                        // Unique ID of command, assigned by Discord
 	Type                     *uint8                      `json:"type,omitempty"`                      // Type of command, defaults to 1
-	ApplicationId            string                      `json:"application_id"`                      // ID of the parent application
-	GuildId                  *string                     `json:"guild_id,omitempty"`                  // Guild ID of the command, if not global
+	ApplicationId            Snowflake                   `json:"application_id,omitempty"`            // ID of the parent application
+	GuildId                  *Snowflake                  `json:"guild_id,omitempty"`                  // Guild ID of the command, if not global
 	Name                     string                      `json:"name"`                                // Name of command, 1-32 characters
 	NameLocalizations        *map[string]string          `json:"name_localizations,omitempty"`        // Localization dictionary for name field. Values follow the same restrictions as name
 	Description              string                      `json:"description"`                         // Description for CHAT_INPUT commands, 1-100 characters. Empty string for USER and MESSAGE commands
 	DescriptionLocalizations *map[string]string          `json:"description_localizations,omitempty"` // Localization dictionary for description field. Values follow the same restrictions as description
 	Options                  *[]ApplicationCommandOption `json:"options,omitempty"`                   // Parameters for the command, max of 25
-	DefaultMemberPermissions *string                     `json:"default_member_permissions"`          // Set of permissions represented as a bit set
+	DefaultMemberPermissions *Permissions                `json:"default_member_permissions"`          // Set of permissions represented as a bit set
 	DmPermissions            *bool                       `json:"dm_permission,omitempty"`             // Deprecated (use contexts instead); Indicates whether the command is available in DMs with the app, only for globally-scoped commands. By default, commands are visible.
 	DefaultPermission        *bool                       `json:"default_permission,omitempty"`        // Not recommended for use as field will soon be deprecated. Indicates whether the command is enabled by default when the app is added to a guild, defaults to true
 	Nsfw                     *bool                       `json:"nsfw,omitempty"`                      // Indicates whether the command is age-restricted, defaults to false
 	IntegrationTypes         *[]uint                     `json:"integration_types,omitempty"`         // Installation contexts where the command is available, only for globally-scoped commands. Defaults to your app's configured contexts
 	Contexts                 *[]uint                     `json:"contexts,omitempty"`                  // Interaction context(s) where the command can be used, only for globally-scoped commands. By default, all interaction context types included for new commands.
-	Version                  string                      `json:"version"`                             // Autoincrementing version identifier updated during substantial record changes
+	Version                  string                      `json:"version,omitempty"`                   // Autoincrementing version identifier updated during substantial record changes
 	Handler                  *uint8                      `json:"handler,omitempty"`                   // Determines whether the interaction is handled by the app's interactions handler or by Discord
 }
 
@@ -64,6 +64,11 @@ type ApplicationCommandOption struct {
 	Choices                  *[]ApplicationCommandOptionChoice `json:"choices,omitempty"`                   //
 	Options                  *[]ApplicationCommandOption       `json:"options,omitempty"`                   //
 	ChannelTypes             *[]int                            `json:"channel_types,omitempty"`             //
+	MinValue                 *float64                          `json:"min_value,omitempty"`                 // Minimum value of an INTEGER or NUMBER option
+	MaxValue                 *float64                          `json:"max_value,omitempty"`                 // Maximum value of an INTEGER or NUMBER option
+	MinLength                *int                              `json:"min_length,omitempty"`                // Minimum length of a STRING option, 0-6000
+	MaxLength                *int                              `json:"max_length,omitempty"`                // Maximum length of a STRING option, 1-6000
+	Autocomplete             *bool                             `json:"autocomplete,omitempty"`              // Whether autocomplete interactions are enabled for a STRING, INTEGER or NUMBER option
 }
 
 // External reference:
@@ -75,15 +80,15 @@ type ApplicationCommandOptionChoice struct {
 
 // External reference: https://discord.com/developers/docs/interactions/application-commands#application-command-permissions-object
 type ApplicationCommandPermissions struct {
-	Id          string                         `json:"id"`             // ID of the command or the application ID
-	AppId       string                         `json:"application_id"` // ID of the application the command belongs to
-	GuildId     string                         `json:"guild_id"`       // ID of the guild
+	Id          Snowflake                      `json:"id"`             // ID of the command or the application ID
+	AppId       Snowflake                      `json:"application_id"` // ID of the application the command belongs to
+	GuildId     Snowflake                      `json:"guild_id"`       // ID of the guild
 	Permissions []ApplicationCommandPermission `json:"permissions"`    // Permissions for the command in the guild, max of 100
 }
 
 // External reference: https://discord.com/developers/docs/interactions/application-commands#application-command-permissions-object-application-command-permissions-structure
 type ApplicationCommandPermission struct {
-	Id         string `json:"id"`         // ID of the role, user, or channel. It can also be a permission constant
-	Type       uint8  `json:"type"`       // role (1), user (2), or channel (3)
-	Permission bool   `json:"permission"` // true to allow, false, to disallow
+	Id         Snowflake `json:"id"`         // ID of the role, user, or channel. It can also be a permission constant
+	Type       uint8     `json:"type"`       // role (1), user (2), or channel (3)
+	Permission bool      `json:"permission"` // true to allow, false, to disallow
 }
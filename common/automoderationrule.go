@@ -2,17 +2,17 @@ package common
 
 // External reference: https://discord.com/developers/docs/resources/auto-moderation#auto-moderation-rule-object
 type AutoModerationRule struct {
-	Id              string          `json:"id"`               // the id of this rule
-	GuildId         string          `json:"guild_id"`         // the id of the guild which this rule belongs to
+	Id              Snowflake       `json:"id"`               // the id of this rule
+	GuildId         Snowflake       `json:"guild_id"`         // the id of the guild which this rule belongs to
 	Name            string          `json:"name"`             // the rule name
-	CreatorId       string          `json:"creator_id"`       // the user which first created this rule
+	CreatorId       Snowflake       `json:"creator_id"`       // the user which first created this rule
 	EventType       int             `json:"event_type"`       // the rule event type
 	TriggerType     int             `json:"trigger_type"`     // the rule trigger type
 	TriggerMetadata TriggerMetadata `json:"trigger_metadata"` // the rule trigger metadata
 	Actions         []Action        `json:"actions"`          // the actions which will execute when the rule is triggered
 	Enabled         bool            `json:"enabled"`          // whether the rule is enabled
-	ExemptRoles     []string        `json:"exempt_roles"`     // the role ids that should not be affected by the rule (Maximum of 20)
-	ExemptChannels  []string        `json:"exempt_channels"`  // the channel ids that should not be affected by the rule (Maximum of 50)
+	ExemptRoles     []Snowflake     `json:"exempt_roles"`     // the role ids that should not be affected by the rule (Maximum of 20)
+	ExemptChannels  []Snowflake     `json:"exempt_channels"`  // the channel ids that should not be affected by the rule (Maximum of 50)
 }
 
 // External reference: https://discord.com/developers/docs/resources/auto-moderation#auto-moderation-rule-object-trigger-metadata
@@ -33,7 +33,7 @@ type Action struct {
 
 // External reference: https://discord.com/developers/docs/resources/auto-moderation#auto-moderation-action-object-action-metadata
 type ActionMetadata struct {
-	ChannelId       string `json:"channel_id"`       // channel to which user content should be logged
-	DurationSeconds int    `json:"duration_seconds"` // timeout duration in seconds
-	CustomMessage   string `json:"custom_message"`   // additional explanation that will be shown to members whenever their message is blocked
+	ChannelId       Snowflake `json:"channel_id"`       // channel to which user content should be logged
+	DurationSeconds int       `json:"duration_seconds"` // timeout duration in seconds
+	CustomMessage   string    `json:"custom_message"`   // additional explanation that will be shown to members whenever their message is blocked
 }
@@ -2,6 +2,6 @@ package common
 
 // External reference:
 type AvatarDecorationData struct {
-	Asset string `json:"asset"`
-	SkuId string `json:"sku_id"`
+	Asset string    `json:"asset"`
+	SkuId Snowflake `json:"sku_id"`
 }
@@ -14,19 +14,19 @@ var EmbedTypes []string = []string{
 
 // Reference: https://discord.com/developers/docs/resources/message#embed-object-embed-structure
 type Embed struct {
-	Title       string          `json:"title"`               // title of embed
-	Type        string          `json:"type"`                // type of embed (always "rich" for webhook embeds)
-	Description string          `json:"description"`         // description of embed
-	Url         string          `json:"url"`                 // url of embed
-	Timestamp   *time.Time      `json:"timestamp,omitempty"` // timestamp of embed content
-	Color       int             `json:"color"`               // color code of the embed
-	Footer      *EmbedFooter    `json:"footer,omitempty"`    // footer information
-	Image       *EmbedImage     `json:"image,omitempty"`     // image information
-	Thumbnail   *EmbedThumbnail `json:"thumbnail,omitempty"` // thumbnail information
-	Video       *EmbedVideo     `json:"video,omitempty"`     // video information
-	Provider    *EmbedProvider  `json:"provider,omitempty"`  // provider information
-	Author      *EmbedAuthor    `json:"author,omitempty"`    // author information
-	Fields      *[]EmbedField   `json:"fields,omitempty"`    // fields information, max of 25
+	Title       string          `json:"title,omitempty"`       // title of embed
+	Type        string          `json:"type,omitempty"`        // type of embed (always "rich" for webhook embeds)
+	Description string          `json:"description,omitempty"` // description of embed
+	Url         string          `json:"url,omitempty"`         // url of embed
+	Timestamp   *time.Time      `json:"timestamp,omitempty"`   // timestamp of embed content
+	Color       int             `json:"color,omitempty"`       // color code of the embed
+	Footer      *EmbedFooter    `json:"footer,omitempty"`      // footer information
+	Image       *EmbedImage     `json:"image,omitempty"`       // image information
+	Thumbnail   *EmbedThumbnail `json:"thumbnail,omitempty"`   // thumbnail information
+	Video       *EmbedVideo     `json:"video,omitempty"`       // video information
+	Provider    *EmbedProvider  `json:"provider,omitempty"`    // provider information
+	Author      *EmbedAuthor    `json:"author,omitempty"`      // author information
+	Fields      *[]EmbedField   `json:"fields,omitempty"`      // fields information, max of 25
 }
 
 type PollResultsEmbed struct {
@@ -36,7 +36,7 @@ type PollResultsEmbed struct {
 
 // Reference: https://discord.com/developers/docs/resources/message#embed-object-embed-footer-structure
 type EmbedFooter struct {
-	Text         *string `json:"text"`                     // footer text
+	Text         string  `json:"text"`                     // footer text
 	IconUrl      *string `json:"icon_url,omitempty"`       // url of footer icon (only supports http(s) and attachments)
 	ProxyIconUrl *string `json:"proxy_icon_url,omitempty"` // a proxied url of footer icon
 }
@@ -0,0 +1,37 @@
+package common
+
+const ( // Interaction Callback Types
+	PongInteractionCallbackType                                 = 1
+	ChannelMessageWithSourceInteractionCallbackType             = 4
+	DeferredChannelMessageWithSourceInteractionCallbackType     = 5
+	DeferredUpdateMessageInteractionCallbackType                = 6
+	UpdateMessageInteractionCallbackType                        = 7
+	ApplicationCommandAutocompleteResultInteractionCallbackType = 8
+	ModalInteractionCallbackType                                = 9
+	LaunchActivityInteractionCallbackType                       = 12
+)
+
+const ( // Message Flags
+	SuppressEmbedsMessageFlag = 1 << 2
+	EphemeralMessageFlag      = 1 << 6
+)
+
+// External reference: https://discord.com/developers/docs/interactions/receiving-and-responding#interaction-response-object
+type InteractionResponse struct {
+	Type uint8                    `json:"type"`           // Type of response
+	Data *InteractionCallbackData `json:"data,omitempty"` // An optional response message
+}
+
+// Fields used depend on the response type: messages, autocomplete results or modals.
+// External reference: https://discord.com/developers/docs/interactions/receiving-and-responding#interaction-response-object-interaction-callback-data-structure
+type InteractionCallbackData struct {
+	Tts             *bool                             `json:"tts,omitempty"`              // Whether the response is TTS
+	Content         *string                           `json:"content,omitempty"`          // Message content
+	Embeds          *[]Embed                          `json:"embeds,omitempty"`           // Supports up to 10 embeds
+	AllowedMentions *AllowedMention                   `json:"allowed_mentions,omitempty"` // Allowed mentions object
+	Flags           *int                              `json:"flags,omitempty"`            // Message flags combined as a bitfield, only SUPPRESS_EMBEDS and EPHEMERAL can be set
+	Components      *[]MessageComponent               `json:"components,omitempty"`       // Message components
+	Choices         *[]ApplicationCommandOptionChoice `json:"choices,omitempty"`          // Autocomplete choices, max of 25
+	CustomId        *string                           `json:"custom_id,omitempty"`        // Developer-defined identifier for a modal, max 100 characters
+	Title           *string                           `json:"title,omitempty"`            // Title of a modal, max 45 characters
+}
@@ -6,8 +6,8 @@ import (
 
 // Reference: https://discord.com/developers/docs/resources/message#message-object
 type Message struct {
-	Id                  string              `json:"id"`
-	ChannelId           string              `json:"channel_id"`
+	Id                  Snowflake           `json:"id"`
+	ChannelId           Snowflake           `json:"channel_id"`
 	Author              User                `json:"author"`
 	Content             string              `json:"content"`
 	Timestamp           time.Time           `json:"timestamp"`
@@ -15,7 +15,7 @@ type Message struct {
 	Tts                 bool                `json:"tts"`
 	MentionEveryone     bool                `json:"mention_everyone"`
 	Mentions            []User              `json:"mentions"`
-	MentionRoles        []Role              `json:"mention_roles"`
+	MentionRoles        []Snowflake         `json:"mention_roles"`
 	MentionChannels     *[]ChannelMention   `json:"mention_channels,omitempty"`
 	ReferencedMessage   *MessageReference   `json:"referenced_message,omitempty"`
 	Attachments         []MessageAttachment `json:"attachments"`
@@ -27,6 +27,17 @@ type Message struct {
 	Flags               *int                `json:"flags,omitempty"`
 }
 
+// Reference: https://discord.com/developers/docs/resources/message#create-message-jsonform-params
+type MessageParams struct {
+	Content          *string             `json:"content,omitempty"`           // Message contents, up to 2000 characters
+	Tts              *bool               `json:"tts,omitempty"`               // Whether this is a TTS message
+	Embeds           *[]Embed            `json:"embeds,omitempty"`            // Up to 10 rich embeds, with up to 6000 characters in total
+	AllowedMentions  *AllowedMention     `json:"allowed_mentions,omitempty"`  // Allowed mentions for the message
+	MessageReference *MessageReference   `json:"message_reference,omitempty"` // Include to make the message a reply or a forward
+	Components       *[]MessageComponent `json:"components,omitempty"`        // Message components
+	Flags            *int                `json:"flags,omitempty"`             // Message flags combined as a bitfield, only SUPPRESS_EMBEDS and SUPPRESS_NOTIFICATIONS can be set
+}
+
 // Reference: https://discord.com/developers/docs/resources/message#allowed-mentions-object-allowed-mention-types
 var AllowedMentionTypes []string = []string{
 	"roles",
@@ -36,10 +47,10 @@ var AllowedMentionTypes []string = []string{
 
 // Reference: https://discord.com/developers/docs/resources/message#allowed-mentions-object-allowed-mentions-structure
 type AllowedMention struct {
-	Parse       *[]string `json:"parse,omitempty"`        // An array of allowed mention types to parse from the content.
-	Roles       *[]string `json:"roles,omitempty"`        // Array of role_ids to mention (Max size of 100)
-	Users       *[]string `json:"users,omitempty"`        // Array of user_ids to mention (Max size of 100)
-	RepliedUser *bool     `json:"replied_user,omitempty"` // For replies, whether to mention the author of the message being replied to (default false)
+	Parse       *[]string    `json:"parse,omitempty"`        // An array of allowed mention types to parse from the content.
+	Roles       *[]Snowflake `json:"roles,omitempty"`        // Array of role_ids to mention (Max size of 100)
+	Users       *[]Snowflake `json:"users,omitempty"`        // Array of user_ids to mention (Max size of 100)
+	RepliedUser *bool        `json:"replied_user,omitempty"` // For replies, whether to mention the author of the message being replied to (default false)
 }
 
 // Reference: https://discord.com/developers/docs/resources/message#channel-mention-object
@@ -48,17 +59,42 @@ type ChannelMention struct {
 
 // Reference: https://discord.com/developers/docs/resources/message#attachment-object
 type MessageAttachment struct {
+	Id           Snowflake `json:"id"`                      // Attachment id
+	Filename     string    `json:"filename"`                // Name of file attached
+	Title        *string   `json:"title,omitempty"`         // The title of the file
+	Description  *string   `json:"description,omitempty"`   // Description for the file (max 1024 characters)
+	ContentType  *string   `json:"content_type,omitempty"`  // The attachment's media type
+	Size         int       `json:"size"`                    // Size of file in bytes
+	Url          string    `json:"url"`                     // Source url of file
+	ProxyUrl     string    `json:"proxy_url"`               // A proxied url of file
+	Height       *int      `json:"height,omitempty"`        // Height of file (if image)
+	Width        *int      `json:"width,omitempty"`         // Width of file (if image)
+	Ephemeral    *bool     `json:"ephemeral,omitempty"`     // Whether this attachment is ephemeral
+	DurationSecs *float64  `json:"duration_secs,omitempty"` // The duration of the audio file (currently for voice messages)
+	Waveform     *string   `json:"waveform,omitempty"`      // Base64 encoded bytearray representing a sampled waveform (currently for voice messages)
+	Flags        *int      `json:"flags,omitempty"`         // Attachment flags combined as a bitfield
 }
 
 // Reference: https://discord.com/developers/docs/resources/message#message-reference-structure
 type MessageReference struct {
-	Type           *int    `json:"type,omitempty"`
-	MessageId      *string `json:"message_id,omitempty"`
-	ChannelId      *string `json:"channel_id,omitempty"`
-	GuildId        *string `json:"guild_id,omitempty"`
-	FailIfNotExist *bool   `json:"fail_if_not_exist,omitempty"`
+	Type           *int       `json:"type,omitempty"`
+	MessageId      *Snowflake `json:"message_id,omitempty"`
+	ChannelId      *Snowflake `json:"channel_id,omitempty"`
+	GuildId        *Snowflake `json:"guild_id,omitempty"`
+	FailIfNotExist *bool      `json:"fail_if_not_exist,omitempty"`
 }
 
+const ( // Message Component Types
+	ActionRowComponentType         = 1
+	ButtonComponentType            = 2
+	StringSelectComponentType      = 3
+	TextInputComponentType         = 4
+	UserSelectComponentType        = 5
+	RoleSelectComponentType        = 6
+	MentionableSelectComponentType = 7
+	ChannelSelectComponentType     = 8
+)
+
 var MessageComponentTypes map[string]int = map[string]int{
 	"Action Row":         1,
 	"Button":             2,
@@ -78,7 +114,7 @@ type MessageComponent struct {
 	Style       *int                `json:"style,omitempty"`
 	CustomId    *string             `json:"custom_id,omitempty"`
 	Emoji       *Emoji              `json:"emoji,omitempty"`
-	SkuId       *string             `json:"sku_id,omitempty"`
+	SkuId       *Snowflake          `json:"sku_id,omitempty"`
 	Url         *string             `json:"url,omitempty"`
 	Disabled    *bool               `json:"disabled,omitempty"`
 	MinLength   *int                `json:"min_length,omitempty"`
@@ -86,14 +122,49 @@ type MessageComponent struct {
 	Placeholder *string             `json:"placeholder,omitempty"`
 	Required    *bool               `json:"required,omitempty"`
 	Value       *string             `json:"value,omitempty"`
+
+	Options       *[]SelectOption       `json:"options,omitempty"`        // Choices of a string select, max of 25
+	ChannelTypes  *[]int                `json:"channel_types,omitempty"`  // Channel types shown in a channel select
+	MinValues     *int                  `json:"min_values,omitempty"`     // Minimum number of items that must be chosen in a select, 0-25
+	MaxValues     *int                  `json:"max_values,omitempty"`     // Maximum number of items that can be chosen in a select, 1-25
+	DefaultValues *[]SelectDefaultValue `json:"default_values,omitempty"` // Preselected items of an auto-populated select
+}
+
+const ( // Button Styles
+	PrimaryButtonStyle   = 1
+	SecondaryButtonStyle = 2
+	SuccessButtonStyle   = 3
+	DangerButtonStyle    = 4
+	LinkButtonStyle      = 5
+	PremiumButtonStyle   = 6
+)
+
+const ( // Text Input Styles
+	ShortTextInputStyle     = 1
+	ParagraphTextInputStyle = 2
+)
+
+// External reference: https://discord.com/developers/docs/interactions/message-components#select-menu-object-select-option-structure
+type SelectOption struct {
+	Label       string  `json:"label"`                 // User-facing name of the option, max 100 characters
+	Value       string  `json:"value"`                 // Developer-defined value of the option, max 100 characters
+	Description *string `json:"description,omitempty"` // Additional description of the option, max 100 characters
+	Emoji       *Emoji  `json:"emoji,omitempty"`       // Partial emoji shown with the option
+	Default     *bool   `json:"default,omitempty"`     // Whether the option is selected by default
+}
+
+// External reference: https://discord.com/developers/docs/interactions/message-components#select-menu-object-select-default-value-structure
+type SelectDefaultValue struct {
+	Id   Snowflake `json:"id"`   // ID of a user, role, or channel
+	Type string    `json:"type"` // Type of value that id represents: "user", "role", or "channel"
 }
 
 type ActionRowComponent struct {
 }
 
 type MessageInteraction struct {
-	User User   `json:"user"`
-	Type int    `json:"type"`
-	Name string `json:"name"`
-	Id   string `json:"id"`
+	User User      `json:"user"`
+	Type int       `json:"type"`
+	Name string    `json:"name"`
+	Id   Snowflake `json:"id"`
 }
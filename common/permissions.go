@@ -0,0 +1,350 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/bits"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A set of permission flags, sent by Discord as a decimal string.
+// Reference: https://discord.com/developers/docs/topics/permissions#permissions-bitwise-permission-flags
+type Permissions uint64
+
+const (
+	PermissionCreateInstantInvite              Permissions = 1 << 0
+	PermissionKickMembers                      Permissions = 1 << 1
+	PermissionBanMembers                       Permissions = 1 << 2
+	PermissionAdministrator                    Permissions = 1 << 3
+	PermissionManageChannels                   Permissions = 1 << 4
+	PermissionManageGuild                      Permissions = 1 << 5
+	PermissionAddReactions                     Permissions = 1 << 6
+	PermissionViewAuditLog                     Permissions = 1 << 7
+	PermissionPrioritySpeaker                  Permissions = 1 << 8
+	PermissionStream                           Permissions = 1 << 9
+	PermissionViewChannel                      Permissions = 1 << 10
+	PermissionSendMessages                     Permissions = 1 << 11
+	PermissionSendTtsMessages                  Permissions = 1 << 12
+	PermissionManageMessages                   Permissions = 1 << 13
+	PermissionEmbedLinks                       Permissions = 1 << 14
+	PermissionAttachFiles                      Permissions = 1 << 15
+	PermissionReadMessageHistory               Permissions = 1 << 16
+	PermissionMentionEveryone                  Permissions = 1 << 17
+	PermissionUseExternalEmojis                Permissions = 1 << 18
+	PermissionViewGuildInsights                Permissions = 1 << 19
+	PermissionConnect                          Permissions = 1 << 20
+	PermissionSpeak                            Permissions = 1 << 21
+	PermissionMuteMembers                      Permissions = 1 << 22
+	PermissionDeafenMembers                    Permissions = 1 << 23
+	PermissionMoveMembers                      Permissions = 1 << 24
+	PermissionUseVad                           Permissions = 1 << 25
+	PermissionChangeNickname                   Permissions = 1 << 26
+	PermissionManageNicknames                  Permissions = 1 << 27
+	PermissionManageRoles                      Permissions = 1 << 28
+	PermissionManageWebhooks                   Permissions = 1 << 29
+	PermissionManageGuildExpressions           Permissions = 1 << 30
+	PermissionUseApplicationCommands           Permissions = 1 << 31
+	PermissionRequestToSpeak                   Permissions = 1 << 32
+	PermissionManageEvents                     Permissions = 1 << 33
+	PermissionManageThreads                    Permissions = 1 << 34
+	PermissionCreatePublicThreads              Permissions = 1 << 35
+	PermissionCreatePrivateThreads             Permissions = 1 << 36
+	PermissionUseExternalStickers              Permissions = 1 << 37
+	PermissionSendMessagesInThreads            Permissions = 1 << 38
+	PermissionUseEmbeddedActivities            Permissions = 1 << 39
+	PermissionModerateMembers                  Permissions = 1 << 40
+	PermissionViewCreatorMonetizationAnalytics Permissions = 1 << 41
+	PermissionUseSoundboard                    Permissions = 1 << 42
+	PermissionCreateGuildExpressions           Permissions = 1 << 43
+	PermissionCreateEvents                     Permissions = 1 << 44
+	PermissionUseExternalSounds                Permissions = 1 << 45
+	PermissionSendVoiceMessages                Permissions = 1 << 46
+	PermissionSendPolls                        Permissions = 1 << 49
+	PermissionUseExternalApps                  Permissions = 1 << 50
+)
+
+var MemberPermissions map[string]Permissions = map[string]Permissions{
+	"CREATE_INSTANT_INVITE":               PermissionCreateInstantInvite,
+	"KICK_MEMBERS":                        PermissionKickMembers,
+	"BAN_MEMBERS":                         PermissionBanMembers,
+	"ADMINISTRATOR":                       PermissionAdministrator,
+	"MANAGE_CHANNELS":                     PermissionManageChannels,
+	"MANAGE_GUILD":                        PermissionManageGuild,
+	"ADD_REACTIONS":                       PermissionAddReactions,
+	"VIEW_AUDIT_LOG":                      PermissionViewAuditLog,
+	"PRIORITY_SPEAKER":                    PermissionPrioritySpeaker,
+	"STREAM":                              PermissionStream,
+	"VIEW_CHANNEL":                        PermissionViewChannel,
+	"SEND_MESSAGES":                       PermissionSendMessages,
+	"SEND_TTS_MESSAGES":                   PermissionSendTtsMessages,
+	"MANAGE_MESSAGES":                     PermissionManageMessages,
+	"EMBED_LINKS":                         PermissionEmbedLinks,
+	"ATTACH_FILES":                        PermissionAttachFiles,
+	"READ_MESSAGE_HISTORY":                PermissionReadMessageHistory,
+	"MENTION_EVERYONE":                    PermissionMentionEveryone,
+	"USE_EXTERNAL_EMOJIS":                 PermissionUseExternalEmojis,
+	"VIEW_GUILD_INSIGHTS":                 PermissionViewGuildInsights,
+	"CONNECT":                             PermissionConnect,
+	"SPEAK":                               PermissionSpeak,
+	"MUTE_MEMBERS":                        PermissionMuteMembers,
+	"DEAFEN_MEMBERS":                      PermissionDeafenMembers,
+	"MOVE_MEMBERS":                        PermissionMoveMembers,
+	"USE_VAD":                             PermissionUseVad,
+	"CHANGE_NICKNAME":                     PermissionChangeNickname,
+	"MANAGE_NICKNAMES":                    PermissionManageNicknames,
+	"MANAGE_ROLES":                        PermissionManageRoles,
+	"MANAGE_WEBHOOKS":                     PermissionManageWebhooks,
+	"MANAGE_GUILD_EXPRESSIONS":            PermissionManageGuildExpressions,
+	"USE_APPLICATION_COMMANDS":            PermissionUseApplicationCommands,
+	"REQUEST_TO_SPEAK":                    PermissionRequestToSpeak,
+	"MANAGE_EVENTS":                       PermissionManageEvents,
+	"MANAGE_THREADS":                      PermissionManageThreads,
+	"CREATE_PUBLIC_THREADS":               PermissionCreatePublicThreads,
+	"CREATE_PRIVATE_THREADS":              PermissionCreatePrivateThreads,
+	"USE_EXTERNAL_STICKERS":               PermissionUseExternalStickers,
+	"SEND_MESSAGES_IN_THREADS":            PermissionSendMessagesInThreads,
+	"USE_EMBEDDED_ACTIVITIES":             PermissionUseEmbeddedActivities,
+	"MODERATE_MEMBERS":                    PermissionModerateMembers,
+	"VIEW_CREATOR_MONETIZATION_ANALYTICS": PermissionViewCreatorMonetizationAnalytics,
+	"USE_SOUNDBOARD":                      PermissionUseSoundboard,
+	"CREATE_GUILD_EXPRESSIONS":            PermissionCreateGuildExpressions,
+	"CREATE_EVENTS":                       PermissionCreateEvents,
+	"USE_EXTERNAL_SOUNDS":                 PermissionUseExternalSounds,
+	"SEND_VOICE_MESSAGES":                 PermissionSendVoiceMessages,
+	"SEND_POLLS":                          PermissionSendPolls,
+	"USE_EXTERNAL_APPS":                   PermissionUseExternalApps,
+}
+
+// Every known permission.
+var AllPermissions Permissions = func() Permissions {
+	var permissions Permissions
+	for _, permission := range MemberPermissions {
+		permissions |= permission
+	}
+	return permissions
+}()
+
+// Reports whether every permission in p is set.
+func (permissions Permissions) Has(p Permissions) bool {
+	return permissions&p == p
+}
+
+// Reports whether any permission in p is set.
+func (permissions Permissions) HasAny(p Permissions) bool {
+	return permissions&p != 0
+}
+
+// Returns the set with the given permissions added.
+func (permissions Permissions) Add(p ...Permissions) Permissions {
+	for _, permission := range p {
+		permissions |= permission
+	}
+	return permissions
+}
+
+// Returns the set with the given permissions removed.
+func (permissions Permissions) Remove(p ...Permissions) Permissions {
+	for _, permission := range p {
+		permissions &^= permission
+	}
+	return permissions
+}
+
+// Returns the names of the set permissions in bit order, joined by "|". Unknown bits are listed by
+// their bit number.
+func (permissions Permissions) String() string {
+
+	if permissions == 0 {
+		return "NONE"
+	}
+
+	names := make(map[Permissions]string, len(MemberPermissions))
+	for name, permission := range MemberPermissions {
+		names[permission] = name
+	}
+
+	var parts []string
+	for remaining := uint64(permissions); remaining != 0; remaining &= remaining - 1 {
+		bit := Permissions(1) << bits.TrailingZeros64(remaining)
+		if name, ok := names[bit]; ok {
+			parts = append(parts, name)
+		} else {
+			parts = append(parts, fmt.Sprintf("1<<%d", bits.TrailingZeros64(remaining)))
+		}
+	}
+
+	return strings.Join(parts, "|")
+}
+
+// Parses permission names separated by "|" or ",", such as "VIEW_CHANNEL|SEND_MESSAGES", or a
+// decimal bit set.
+func ParsePermissions(value string) (Permissions, error) {
+
+	value = strings.TrimSpace(value)
+	if value == "" || value == "NONE" {
+		return 0, nil
+	}
+
+	if number, err := strconv.ParseUint(value, 10, 64); err == nil {
+		return Permissions(number), nil
+	}
+
+	var permissions Permissions
+	for _, name := range strings.FieldsFunc(value, func(r rune) bool { return r == '|' || r == ',' }) {
+
+		permission, ok := MemberPermissions[strings.ToUpper(strings.TrimSpace(name))]
+		if !ok {
+			return 0, fmt.Errorf("unknown permission %q", strings.TrimSpace(name))
+		}
+
+		permissions |= permission
+	}
+
+	return permissions, nil
+}
+
+func (permissions Permissions) MarshalJSON() ([]byte, error) {
+	return json.Marshal(strconv.FormatUint(uint64(permissions), 10))
+}
+
+// Accepts Discord's decimal string form, as well as plain numbers.
+func (permissions *Permissions) UnmarshalJSON(data []byte) error {
+
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		var number uint64
+		if err := json.Unmarshal(data, &number); err != nil {
+			return fmt.Errorf("invalid permissions %s", data)
+		}
+		*permissions = Permissions(number)
+		return nil
+	}
+
+	if value == "" {
+		*permissions = 0
+		return nil
+	}
+
+	number, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid permissions %q: %w", value, err)
+	}
+
+	*permissions = Permissions(number)
+	return nil
+}
+
+// Overwrite types
+const (
+	OverwriteTypeRole   = 0
+	OverwriteTypeMember = 1
+)
+
+// Permissions kept by members that are timed out.
+// Reference: https://discord.com/developers/docs/topics/permissions#implicit-permissions
+const timedOutPermissions Permissions = PermissionViewChannel | PermissionReadMessageHistory
+
+// Permissions that are implicitly denied when a member cannot send messages.
+const sendMessagePermissions Permissions = PermissionMentionEveryone | PermissionSendTtsMessages | PermissionAttachFiles | PermissionEmbedLinks
+
+// Returns a member's guild-wide permissions: the union of the @everyone role and the member's roles,
+// or every permission for the guild owner and administrators. guild.Roles must be populated.
+// Reference: https://discord.com/developers/docs/topics/permissions#permission-overwrites
+func BasePermissions(guild *Guild, member *Member) Permissions {
+
+	if member.User != nil && member.User.Id == guild.OwnerId {
+		return AllPermissions
+	}
+
+	var permissions Permissions
+	for _, role := range guild.Roles {
+		if role.Id == guild.Id || slices.Contains(member.Roles, role.Id) { // The @everyone role shares the guild's ID
+			permissions |= role.Permissions
+		}
+	}
+
+	if permissions&PermissionAdministrator != 0 {
+		return AllPermissions
+	}
+
+	if timedOut(member) {
+		permissions &= timedOutPermissions
+	}
+
+	return permissions
+}
+
+// Returns a member's permissions in a channel, applying the channel's overwrites to the member's base
+// permissions in order: @everyone, then the member's roles, then the member. Threads have no
+// overwrites of their own, so pass a thread's parent channel to compute permissions in a thread.
+func EffectivePermissions(guild *Guild, member *Member, channel *Channel) Permissions {
+	return ApplyOverwrites(BasePermissions(guild, member), guild, member, channel)
+}
+
+// Applies a channel's overwrites and implicit denials to a member's base permissions.
+func ApplyOverwrites(base Permissions, guild *Guild, member *Member, channel *Channel) Permissions {
+
+	// Administrators and owners are unaffected by overwrites
+	if base&PermissionAdministrator != 0 || (member.User != nil && member.User.Id == guild.OwnerId) {
+		return AllPermissions
+	}
+
+	permissions := base
+
+	var overwrites []Overwrite
+	if channel.PermissionOverwrites != nil {
+		overwrites = *channel.PermissionOverwrites
+	}
+
+	// @everyone
+	for _, overwrite := range overwrites {
+		if overwrite.Type == OverwriteTypeRole && overwrite.Id == guild.Id {
+			permissions &^= overwrite.Deny
+			permissions |= overwrite.Allow
+		}
+	}
+
+	// Roles, combined so that an allow on any role wins over a deny on another
+	var allow, deny Permissions
+	for _, overwrite := range overwrites {
+		if overwrite.Type == OverwriteTypeRole && overwrite.Id != guild.Id && slices.Contains(member.Roles, overwrite.Id) {
+			allow |= overwrite.Allow
+			deny |= overwrite.Deny
+		}
+	}
+	permissions &^= deny
+	permissions |= allow
+
+	// Member
+	if member.User != nil {
+		for _, overwrite := range overwrites {
+			if overwrite.Type == OverwriteTypeMember && overwrite.Id == member.User.Id {
+				permissions &^= overwrite.Deny
+				permissions |= overwrite.Allow
+			}
+		}
+	}
+
+	// Implicit denials
+	if timedOut(member) {
+		permissions &= timedOutPermissions
+	}
+
+	if permissions&PermissionViewChannel == 0 {
+		return 0
+	}
+
+	if permissions&PermissionSendMessages == 0 {
+		permissions &^= sendMessagePermissions
+	}
+
+	return permissions
+}
+
+// Reports whether a member is currently timed out.
+func timedOut(member *Member) bool {
+	return member.CommunicationDisabledUntil != nil && member.CommunicationDisabledUntil.After(time.Now())
+}
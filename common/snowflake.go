@@ -0,0 +1,146 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Milliseconds between the Unix epoch and the Discord epoch, the first second of 2015.
+const DiscordEpoch = 1420070400000
+
+// A unique Discord ID. Snowflakes are sent as decimal strings and sort by creation time. The zero
+// value represents no ID.
+// External reference: https://discord.com/developers/docs/reference#snowflakes
+type Snowflake uint64
+
+// Parses a snowflake from its decimal form.
+func ParseSnowflake(value string) (Snowflake, error) {
+
+	id, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid snowflake %q: %w", value, err)
+	}
+
+	return Snowflake(id), nil
+}
+
+// Returns the smallest snowflake created at t, for use as a before or after pagination bound.
+func SnowflakeFromTime(t time.Time) Snowflake {
+
+	ms := t.UnixMilli() - DiscordEpoch
+	if ms < 0 {
+		return 0
+	}
+
+	return Snowflake(ms) << 22
+}
+
+// Returns the time the snowflake was created.
+func (s Snowflake) Time() time.Time {
+	return time.UnixMilli(int64(s>>22) + DiscordEpoch)
+}
+
+// Returns the internal ID of the worker that generated the snowflake.
+func (s Snowflake) WorkerId() uint8 {
+	return uint8((s & 0x3E0000) >> 17)
+}
+
+// Returns the internal ID of the process that generated the snowflake.
+func (s Snowflake) ProcessId() uint8 {
+	return uint8((s & 0x1F000) >> 12)
+}
+
+// Returns the number of snowflakes the process had generated before this one, within the same millisecond.
+func (s Snowflake) Increment() uint16 {
+	return uint16(s & 0xFFF)
+}
+
+// Reports whether the snowflake is unset.
+func (s Snowflake) IsZero() bool {
+	return s == 0
+}
+
+// Reports whether the snowflake was created before other.
+func (s Snowflake) Before(other Snowflake) bool {
+	return s < other
+}
+
+// Reports whether the snowflake was created after other.
+func (s Snowflake) After(other Snowflake) bool {
+	return s > other
+}
+
+// Returns -1, 0 or +1 depending on whether the snowflake sorts before, equal to or after other.
+func (s Snowflake) Compare(other Snowflake) int {
+	switch {
+	case s < other:
+		return -1
+	case s > other:
+		return 1
+	}
+	return 0
+}
+
+func (s Snowflake) String() string {
+	return strconv.FormatUint(uint64(s), 10)
+}
+
+// Encodes the snowflake as a decimal string, or null when unset.
+func (s Snowflake) MarshalJSON() ([]byte, error) {
+
+	if s == 0 {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(s.String())
+}
+
+// Accepts decimal strings and plain numbers; null and empty strings decode to the zero snowflake.
+func (s *Snowflake) UnmarshalJSON(data []byte) error {
+
+	if string(data) == "null" {
+		*s = 0
+		return nil
+	}
+
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		var number uint64
+		if err := json.Unmarshal(data, &number); err != nil {
+			return fmt.Errorf("invalid snowflake %s", data)
+		}
+		*s = Snowflake(number)
+		return nil
+	}
+
+	if value == "" {
+		*s = 0
+		return nil
+	}
+
+	id, err := ParseSnowflake(value)
+	if err != nil {
+		return err
+	}
+
+	*s = id
+	return nil
+}
+
+// Encodes the snowflake as text, allowing snowflakes as JSON object keys.
+func (s Snowflake) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+func (s *Snowflake) UnmarshalText(text []byte) error {
+
+	id, err := ParseSnowflake(string(text))
+	if err != nil {
+		return err
+	}
+
+	*s = id
+	return nil
+}
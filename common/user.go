@@ -2,7 +2,7 @@ package common
 
 // External Reference: https://discord.com/developers/docs/resources/user#user-object
 type User struct {
-	Id                   string                `json:"id"`                               // The user's id
+	Id                   Snowflake             `json:"id"`                               // The user's id
 	Username             string                `json:"username"`                         // The user's username, not unique across the platform
 	Discriminator        string                `json:"discriminator"`                    // The user's Discord-tag
 	GlobalName           *string               `json:"global_name"`                      // The user's display name, if it is set. For bots, this is the application name
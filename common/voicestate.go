@@ -6,9 +6,9 @@ import (
 
 // External reference: https://discord.com/developers/docs/resources/voice#voice-state-object
 type VoiceState struct {
-	GuildId                 *string    `json:"guild_id,omitempty"`         // the guild id this voice state is for
-	ChannelId               *string    `json:"channel_id"`                 // the channel id this user is connected to
-	UserId                  string     `json:"user_id"`                    // the user id this voice state is for
+	GuildId                 *Snowflake `json:"guild_id,omitempty"`         // the guild id this voice state is for
+	ChannelId               *Snowflake `json:"channel_id"`                 // the channel id this user is connected to
+	UserId                  Snowflake  `json:"user_id"`                    // the user id this voice state is for
 	Member                  *Member    `json:"member,omitempty"`           // the guild member this voice state is for
 	SessionId               string     `json:"session_id"`                 // the session id for this voice state
 	Deaf                    bool       `json:"deaf"`                       // whether this user is deafened by the server
@@ -0,0 +1,676 @@
+package components
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"unicode/utf8"
+
+	"brandenly.com/go/packages/discord-bot/common"
+)
+
+// Component limits.
+// External reference: https://discord.com/developers/docs/interactions/message-components
+const (
+	MaxActionRows           = 5    // Maximum action rows in a message or modal
+	MaxButtonsPerRow        = 5    // Maximum buttons in an action row
+	MaxButtonLabelLength    = 80   // Maximum length of a button label
+	MaxSelectOptions        = 25   // Maximum options of a string select, and items chosen in any select
+	MaxSelectOptionLength   = 100  // Maximum length of a select option's label, value and description
+	MaxPlaceholderLength    = 150  // Maximum length of a select placeholder
+	MaxTextInputLabel       = 45   // Maximum length of a text input label
+	MaxTextInputLength      = 4000 // Maximum length limit of a text input's value
+	MaxTextInputPlaceholder = 100  // Maximum length of a text input placeholder
+	MaxModalTitleLength     = 45   // Maximum length of a modal title
+)
+
+// Builds a single component. Build reports every way the component breaks Discord's rules.
+type Builder interface {
+	Build() (common.MessageComponent, error)
+}
+
+//// Action Rows
+
+// Builds an action row, holding up to five buttons or a single select menu in messages, or a single
+// text input in modals.
+type ActionRowBuilder struct {
+	components []Builder
+}
+
+// Returns an action row holding components.
+func Row(components ...Builder) *ActionRowBuilder {
+	return &ActionRowBuilder{components: components}
+}
+
+// Adds components to the row.
+func (b *ActionRowBuilder) Add(components ...Builder) *ActionRowBuilder {
+	b.components = append(b.components, components...)
+	return b
+}
+
+// Builds the row for a message.
+func (b *ActionRowBuilder) Build() (common.MessageComponent, error) {
+
+	row, children, err := b.build()
+
+	errs := []error{err}
+	buttons, selects := 0, 0
+
+	for i, child := range children {
+		switch child.Type {
+		case common.ButtonComponentType:
+			buttons++
+		case common.StringSelectComponentType, common.UserSelectComponentType, common.RoleSelectComponentType, common.MentionableSelectComponentType, common.ChannelSelectComponentType:
+			selects++
+		case common.TextInputComponentType:
+			errs = append(errs, fmt.Errorf("component %d: text inputs can only be used in modals", i+1))
+		default:
+			errs = append(errs, fmt.Errorf("component %d: type %d can't be placed in an action row", i+1, child.Type))
+		}
+	}
+
+	if buttons > MaxButtonsPerRow {
+		errs = append(errs, fmt.Errorf("%d buttons, more than the maximum of %d", buttons, MaxButtonsPerRow))
+	}
+
+	if selects > 0 && len(children) > 1 {
+		errs = append(errs, fmt.Errorf("a select menu must be the only component in its row"))
+	}
+
+	return row, errors.Join(errs...)
+}
+
+// Builds the row's children, returning the row holding them.
+func (b *ActionRowBuilder) build() (common.MessageComponent, []common.MessageComponent, error) {
+
+	row := common.MessageComponent{Type: common.ActionRowComponentType}
+
+	if len(b.components) == 0 {
+		return row, nil, fmt.Errorf("action row is empty")
+	}
+
+	var errs []error
+	children := make([]common.MessageComponent, 0, len(b.components))
+
+	for i, builder := range b.components {
+
+		child, err := builder.Build()
+		if err != nil {
+			errs = append(errs, within(fmt.Sprintf("component %d", i+1), err)...)
+		}
+
+		children = append(children, child)
+	}
+
+	row.Components = &children
+	return row, children, errors.Join(errs...)
+}
+
+// Builds the components of a message from its action rows, validating each and that custom_ids are
+// unique.
+func Build(rows ...*ActionRowBuilder) ([]common.MessageComponent, error) {
+
+	var errs []error
+	if len(rows) > MaxActionRows {
+		errs = append(errs, fmt.Errorf("%d action rows, more than the maximum of %d", len(rows), MaxActionRows))
+	}
+
+	components := make([]common.MessageComponent, 0, len(rows))
+	for i, row := range rows {
+
+		component, err := row.Build()
+		if err != nil {
+			errs = append(errs, within(fmt.Sprintf("row %d", i+1), err)...)
+		}
+
+		components = append(components, component)
+	}
+
+	errs = append(errs, checkCustomIds(components)...)
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	return components, nil
+}
+
+// Reports custom_ids used more than once.
+func checkCustomIds(components []common.MessageComponent) []error {
+
+	var errs []error
+	seen := map[string]bool{}
+
+	var walk func(components []common.MessageComponent)
+	walk = func(components []common.MessageComponent) {
+		for _, component := range components {
+
+			if component.CustomId != nil {
+				if seen[*component.CustomId] {
+					errs = append(errs, fmt.Errorf("custom_id %q is used more than once", *component.CustomId))
+				}
+				seen[*component.CustomId] = true
+			}
+
+			if component.Components != nil {
+				walk(*component.Components)
+			}
+		}
+	}
+
+	walk(components)
+	return errs
+}
+
+//// Buttons
+
+// Builds a button.
+type ButtonBuilder struct {
+	component common.MessageComponent
+}
+
+// Returns a button that sends an interaction with customId when clicked, styled as style.
+func Button(style int, customId string, label string) *ButtonBuilder {
+	return &ButtonBuilder{component: common.MessageComponent{
+		Type:     common.ButtonComponentType,
+		Style:    &style,
+		CustomId: &customId,
+		Label:    optional(label),
+	}}
+}
+
+// Returns a button that opens url when clicked; it sends no interaction.
+func LinkButton(url string, label string) *ButtonBuilder {
+
+	style := common.LinkButtonStyle
+
+	return &ButtonBuilder{component: common.MessageComponent{
+		Type:  common.ButtonComponentType,
+		Style: &style,
+		Url:   &url,
+		Label: optional(label),
+	}}
+}
+
+// Returns a button that lets users purchase an SKU. Premium buttons can't have a label, emoji,
+// custom_id or url.
+func PremiumButton(skuId common.Snowflake) *ButtonBuilder {
+
+	style := common.PremiumButtonStyle
+
+	return &ButtonBuilder{component: common.MessageComponent{
+		Type:  common.ButtonComponentType,
+		Style: &style,
+		SkuId: &skuId,
+	}}
+}
+
+// Sets the button's emoji, such as a partial emoji with a unicode name or a custom emoji id.
+func (b *ButtonBuilder) Emoji(emoji common.Emoji) *ButtonBuilder {
+	b.component.Emoji = &emoji
+	return b
+}
+
+// Sets whether the button is disabled.
+func (b *ButtonBuilder) Disabled(disabled bool) *ButtonBuilder {
+	b.component.Disabled = &disabled
+	return b
+}
+
+func (b *ButtonBuilder) Build() (common.MessageComponent, error) {
+
+	component := b.component
+	var errs []error
+
+	style := 0
+	if component.Style != nil {
+		style = *component.Style
+	}
+
+	switch style {
+
+	case common.LinkButtonStyle:
+		if component.Url == nil || *component.Url == "" {
+			errs = append(errs, fmt.Errorf("link buttons need a url"))
+		}
+		if component.CustomId != nil {
+			errs = append(errs, fmt.Errorf("link buttons can't have a custom_id"))
+		}
+
+	case common.PremiumButtonStyle:
+		if component.SkuId == nil || component.SkuId.IsZero() {
+			errs = append(errs, fmt.Errorf("premium buttons need an sku_id"))
+		}
+		if component.CustomId != nil || component.Url != nil || component.Label != nil || component.Emoji != nil {
+			errs = append(errs, fmt.Errorf("premium buttons can't have a custom_id, url, label or emoji"))
+		}
+
+	case common.PrimaryButtonStyle, common.SecondaryButtonStyle, common.SuccessButtonStyle, common.DangerButtonStyle:
+		errs = append(errs, checkCustomId(component.CustomId)...)
+		if component.Url != nil {
+			errs = append(errs, fmt.Errorf("only link buttons can have a url"))
+		}
+
+	default:
+		errs = append(errs, fmt.Errorf("unknown button style %d", style))
+	}
+
+	if style != common.PremiumButtonStyle && component.Label == nil && component.Emoji == nil {
+		errs = append(errs, fmt.Errorf("buttons need a label or emoji"))
+	}
+
+	errs = append(errs, checkLength("label", component.Label, MaxButtonLabelLength)...)
+
+	return component, errors.Join(errs...)
+}
+
+//// Select Menus
+
+// Builds a select menu of any type.
+type SelectBuilder struct {
+	component common.MessageComponent
+}
+
+// Returns a select menu of developer-defined options.
+func StringSelect(customId string, options ...common.SelectOption) *SelectBuilder {
+
+	b := newSelect(common.StringSelectComponentType, customId)
+	b.component.Options = &options
+	return b
+}
+
+// Returns a select menu of users.
+func UserSelect(customId string) *SelectBuilder {
+	return newSelect(common.UserSelectComponentType, customId)
+}
+
+// Returns a select menu of roles.
+func RoleSelect(customId string) *SelectBuilder {
+	return newSelect(common.RoleSelectComponentType, customId)
+}
+
+// Returns a select menu of users and roles.
+func MentionableSelect(customId string) *SelectBuilder {
+	return newSelect(common.MentionableSelectComponentType, customId)
+}
+
+// Returns a select menu of channels, optionally limited to channelTypes.
+func ChannelSelect(customId string, channelTypes ...int) *SelectBuilder {
+
+	b := newSelect(common.ChannelSelectComponentType, customId)
+	if len(channelTypes) > 0 {
+		b.component.ChannelTypes = &channelTypes
+	}
+	return b
+}
+
+func newSelect(componentType int, customId string) *SelectBuilder {
+	return &SelectBuilder{component: common.MessageComponent{Type: componentType, CustomId: &customId}}
+}
+
+// Returns a string select option.
+func Option(label string, value string) common.SelectOption {
+	return common.SelectOption{Label: label, Value: value}
+}
+
+// Adds an option to a string select.
+func (b *SelectBuilder) Option(option common.SelectOption) *SelectBuilder {
+
+	if b.component.Options == nil {
+		b.component.Options = &[]common.SelectOption{}
+	}
+
+	*b.component.Options = append(*b.component.Options, option)
+	return b
+}
+
+// Sets the text shown when nothing is selected.
+func (b *SelectBuilder) Placeholder(placeholder string) *SelectBuilder {
+	b.component.Placeholder = &placeholder
+	return b
+}
+
+// Sets the minimum and maximum number of items that can be chosen.
+func (b *SelectBuilder) Values(min int, max int) *SelectBuilder {
+	b.component.MinValues = &min
+	b.component.MaxValues = &max
+	return b
+}
+
+// Sets whether the select menu is disabled.
+func (b *SelectBuilder) Disabled(disabled bool) *SelectBuilder {
+	b.component.Disabled = &disabled
+	return b
+}
+
+// Preselects users in a user or mentionable select.
+func (b *SelectBuilder) DefaultUsers(ids ...common.Snowflake) *SelectBuilder {
+	return b.defaults("user", ids)
+}
+
+// Preselects roles in a role or mentionable select.
+func (b *SelectBuilder) DefaultRoles(ids ...common.Snowflake) *SelectBuilder {
+	return b.defaults("role", ids)
+}
+
+// Preselects channels in a channel select.
+func (b *SelectBuilder) DefaultChannels(ids ...common.Snowflake) *SelectBuilder {
+	return b.defaults("channel", ids)
+}
+
+func (b *SelectBuilder) defaults(valueType string, ids []common.Snowflake) *SelectBuilder {
+
+	if b.component.DefaultValues == nil {
+		b.component.DefaultValues = &[]common.SelectDefaultValue{}
+	}
+
+	for _, id := range ids {
+		*b.component.DefaultValues = append(*b.component.DefaultValues, common.SelectDefaultValue{Id: id, Type: valueType})
+	}
+
+	return b
+}
+
+// Default value types each auto-populated select accepts.
+var selectDefaultTypes map[int][]string = map[int][]string{
+	common.UserSelectComponentType:        {"user"},
+	common.RoleSelectComponentType:        {"role"},
+	common.MentionableSelectComponentType: {"user", "role"},
+	common.ChannelSelectComponentType:     {"channel"},
+}
+
+func (b *SelectBuilder) Build() (common.MessageComponent, error) {
+
+	component := b.component
+	errs := checkCustomId(component.CustomId)
+	errs = append(errs, checkLength("placeholder", component.Placeholder, MaxPlaceholderLength)...)
+
+	min, max := 1, 1
+	if component.MinValues != nil {
+		min = *component.MinValues
+	}
+	if component.MaxValues != nil {
+		max = *component.MaxValues
+	}
+
+	if min < 0 || min > MaxSelectOptions {
+		errs = append(errs, fmt.Errorf("min_values must be 0-%d, got %d", MaxSelectOptions, min))
+	}
+	if max < 1 || max > MaxSelectOptions {
+		errs = append(errs, fmt.Errorf("max_values must be 1-%d, got %d", MaxSelectOptions, max))
+	}
+	if min > max {
+		errs = append(errs, fmt.Errorf("min_values %d is greater than max_values %d", min, max))
+	}
+
+	if component.Type == common.StringSelectComponentType {
+
+		var options []common.SelectOption
+		if component.Options != nil {
+			options = *component.Options
+		}
+
+		if len(options) == 0 || len(options) > MaxSelectOptions {
+			errs = append(errs, fmt.Errorf("string selects need 1-%d options, got %d", MaxSelectOptions, len(options)))
+		}
+		if min > len(options) {
+			errs = append(errs, fmt.Errorf("min_values %d is more than the %d options", min, len(options)))
+		}
+
+		values, defaults := map[string]bool{}, 0
+		for i, option := range options {
+
+			if option.Label == "" || option.Value == "" {
+				errs = append(errs, fmt.Errorf("option %d: needs a label and value", i+1))
+			}
+
+			errs = append(errs, within(fmt.Sprintf("option %d", i+1), errors.Join(append(append(
+				checkLength("label", &option.Label, MaxSelectOptionLength),
+				checkLength("value", &option.Value, MaxSelectOptionLength)...),
+				checkLength("description", option.Description, MaxSelectOptionLength)...)...))...)
+
+			if values[option.Value] {
+				errs = append(errs, fmt.Errorf("option %d: value %q is used more than once", i+1, option.Value))
+			}
+			values[option.Value] = true
+
+			if option.Default != nil && *option.Default {
+				defaults++
+			}
+		}
+
+		if defaults > max {
+			errs = append(errs, fmt.Errorf("%d default options, more than max_values %d", defaults, max))
+		}
+
+		if component.DefaultValues != nil {
+			errs = append(errs, fmt.Errorf("string selects preselect options with SelectOption.Default, not default values"))
+		}
+
+	} else {
+
+		if component.Options != nil {
+			errs = append(errs, fmt.Errorf("only string selects can have options"))
+		}
+
+		if component.ChannelTypes != nil && component.Type != common.ChannelSelectComponentType {
+			errs = append(errs, fmt.Errorf("only channel selects can restrict channel types"))
+		}
+
+		if component.DefaultValues != nil {
+
+			defaults := *component.DefaultValues
+			if len(defaults) > max {
+				errs = append(errs, fmt.Errorf("%d default values, more than max_values %d", len(defaults), max))
+			}
+
+			for i, value := range defaults {
+				if !slices.Contains(selectDefaultTypes[component.Type], value.Type) {
+					errs = append(errs, fmt.Errorf("default value %d: type %q can't be used in this select", i+1, value.Type))
+				}
+			}
+		}
+	}
+
+	return component, errors.Join(errs...)
+}
+
+//// Text Inputs
+
+// Builds a text input, which can only be used in modals.
+type TextInputBuilder struct {
+	component common.MessageComponent
+}
+
+// Returns a single-line text input.
+func TextInput(customId string, label string) *TextInputBuilder {
+
+	style := common.ShortTextInputStyle
+
+	return &TextInputBuilder{component: common.MessageComponent{
+		Type:     common.TextInputComponentType,
+		CustomId: &customId,
+		Label:    &label,
+		Style:    &style,
+	}}
+}
+
+// Makes the text input span multiple lines.
+func (b *TextInputBuilder) Paragraph() *TextInputBuilder {
+	style := common.ParagraphTextInputStyle
+	b.component.Style = &style
+	return b
+}
+
+// Sets the minimum and maximum length of the input.
+func (b *TextInputBuilder) Length(min int, max int) *TextInputBuilder {
+	b.component.MinLength = &min
+	b.component.MaxLength = &max
+	return b
+}
+
+// Sets whether the input must be filled in; inputs are required by default.
+func (b *TextInputBuilder) Required(required bool) *TextInputBuilder {
+	b.component.Required = &required
+	return b
+}
+
+// Prefills the input.
+func (b *TextInputBuilder) Value(value string) *TextInputBuilder {
+	b.component.Value = &value
+	return b
+}
+
+// Sets the text shown when the input is empty.
+func (b *TextInputBuilder) Placeholder(placeholder string) *TextInputBuilder {
+	b.component.Placeholder = &placeholder
+	return b
+}
+
+func (b *TextInputBuilder) Build() (common.MessageComponent, error) {
+
+	component := b.component
+	errs := checkCustomId(component.CustomId)
+
+	if component.Label == nil || *component.Label == "" {
+		errs = append(errs, fmt.Errorf("text inputs need a label"))
+	}
+
+	errs = append(errs, checkLength("label", component.Label, MaxTextInputLabel)...)
+	errs = append(errs, checkLength("value", component.Value, MaxTextInputLength)...)
+	errs = append(errs, checkLength("placeholder", component.Placeholder, MaxTextInputPlaceholder)...)
+
+	if component.Style == nil || (*component.Style != common.ShortTextInputStyle && *component.Style != common.ParagraphTextInputStyle) {
+		errs = append(errs, fmt.Errorf("unknown text input style"))
+	}
+
+	if component.MinLength != nil && (*component.MinLength < 0 || *component.MinLength > MaxTextInputLength) {
+		errs = append(errs, fmt.Errorf("min_length must be 0-%d, got %d", MaxTextInputLength, *component.MinLength))
+	}
+
+	if component.MaxLength != nil && (*component.MaxLength < 1 || *component.MaxLength > MaxTextInputLength) {
+		errs = append(errs, fmt.Errorf("max_length must be 1-%d, got %d", MaxTextInputLength, *component.MaxLength))
+	}
+
+	if component.MinLength != nil && component.MaxLength != nil && *component.MinLength > *component.MaxLength {
+		errs = append(errs, fmt.Errorf("min_length %d is greater than max_length %d", *component.MinLength, *component.MaxLength))
+	}
+
+	if component.Value != nil && component.MaxLength != nil && utf8.RuneCountInString(*component.Value) > *component.MaxLength {
+		errs = append(errs, fmt.Errorf("value is longer than max_length %d", *component.MaxLength))
+	}
+
+	return component, errors.Join(errs...)
+}
+
+//// Modals
+
+// Builds a modal: a popup form of text inputs.
+type ModalBuilder struct {
+	customId string
+	title    string
+	inputs   []*TextInputBuilder
+}
+
+// Returns a modal titled title that sends an interaction with customId when submitted.
+func Modal(customId string, title string, inputs ...*TextInputBuilder) *ModalBuilder {
+	return &ModalBuilder{customId: customId, title: title, inputs: inputs}
+}
+
+// Adds text inputs to the modal, each in its own row.
+func (b *ModalBuilder) Add(inputs ...*TextInputBuilder) *ModalBuilder {
+	b.inputs = append(b.inputs, inputs...)
+	return b
+}
+
+// Builds the modal as the data of a modal interaction response.
+func (b *ModalBuilder) Build() (common.InteractionCallbackData, error) {
+
+	errs := checkCustomId(&b.customId)
+
+	if b.title == "" {
+		errs = append(errs, fmt.Errorf("modals need a title"))
+	}
+	errs = append(errs, checkLength("title", &b.title, MaxModalTitleLength)...)
+
+	if len(b.inputs) == 0 || len(b.inputs) > MaxActionRows {
+		errs = append(errs, fmt.Errorf("modals need 1-%d text inputs, got %d", MaxActionRows, len(b.inputs)))
+	}
+
+	rows := make([]common.MessageComponent, 0, len(b.inputs))
+	for i, input := range b.inputs {
+
+		row, _, err := Row(input).build()
+		if err != nil {
+			errs = append(errs, within(fmt.Sprintf("row %d", i+1), err)...)
+		}
+
+		rows = append(rows, row)
+	}
+
+	errs = append(errs, checkCustomIds(rows)...)
+
+	if err := errors.Join(errs...); err != nil {
+		return common.InteractionCallbackData{}, errors.Join(within(fmt.Sprintf("modal %q", b.customId), err)...)
+	}
+
+	customId, title := b.customId, b.title
+	return common.InteractionCallbackData{CustomId: &customId, Title: &title, Components: &rows}, nil
+}
+
+//// Checks
+
+func checkCustomId(customId *string) []error {
+
+	if customId == nil || *customId == "" {
+		return []error{fmt.Errorf("custom_id is required")}
+	}
+
+	if n := utf8.RuneCountInString(*customId); n > MaxCustomIdLength {
+		return []error{fmt.Errorf("custom_id is %d characters, more than the maximum of %d", n, MaxCustomIdLength)}
+	}
+
+	return nil
+}
+
+func checkLength(field string, value *string, max int) []error {
+
+	if value == nil {
+		return nil
+	}
+
+	if n := utf8.RuneCountInString(*value); n > max {
+		return []error{fmt.Errorf("%s is %d characters, more than the maximum of %d", field, n, max)}
+	}
+
+	return nil
+}
+
+// Returns the errors joined in err, each prefixed with context.
+func within(context string, err error) []error {
+
+	if err == nil {
+		return nil
+	}
+
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+
+		var errs []error
+		for _, err := range joined.Unwrap() {
+			errs = append(errs, within(context, err)...)
+		}
+
+		return errs
+	}
+
+	return []error{fmt.Errorf("%s: %w", context, err)}
+}
+
+func optional(value string) *string {
+
+	if value == "" {
+		return nil
+	}
+
+	return &value
+}
@@ -0,0 +1,173 @@
+package components
+
+import (
+	"context"
+
+	"brandenly.com/go/packages/discord-bot/common"
+	"brandenly.com/go/packages/discord-bot/discord"
+	"brandenly.com/go/packages/discord-bot/gateway"
+)
+
+// A component interaction routed to a handler.
+type Context struct {
+	context.Context
+	responder
+	Data   *gateway.MessageComponentData // Decoded component data of the interaction
+	Params map[string]string             // Parameters captured from the custom_id by the handler's pattern
+}
+
+// The interaction being handled, shared by component and modal contexts.
+type responder struct {
+	App         *discord.App               // Application that received the interaction
+	Interaction *gateway.InteractionCreate // The interaction being handled
+}
+
+// Returns a parameter captured from the custom_id.
+func (c *Context) Param(name string) string {
+	return c.Params[name]
+}
+
+// Decodes state embedded in a custom_id parameter by codec.
+func (c *Context) State(codec *Codec, param string, dst any) error {
+	return codec.Decode(c.Params[param], dst)
+}
+
+//// Select Values
+
+// Returns the values selected in a string select menu.
+func (c *Context) Values() []string {
+	return c.Data.Values
+}
+
+// Returns the IDs selected in a user, role, mentionable or channel select menu.
+func (c *Context) Snowflakes() []common.Snowflake {
+
+	ids := make([]common.Snowflake, 0, len(c.Data.Values))
+	for _, value := range c.Data.Values {
+		if id, err := common.ParseSnowflake(value); err == nil {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
+
+// Returns the users selected in a user or mentionable select menu.
+func (c *Context) Users() []common.User {
+	return resolveSelected(c, c.Data.Resolved.User)
+}
+
+// Returns the guild members selected in a user or mentionable select menu.
+func (c *Context) Members() []common.Member {
+	return resolveSelected(c, c.Data.Resolved.Member)
+}
+
+// Returns the roles selected in a role or mentionable select menu.
+func (c *Context) Roles() []common.Role {
+	return resolveSelected(c, c.Data.Resolved.Role)
+}
+
+// Returns the channels selected in a channel select menu.
+func (c *Context) Channels() []common.Channel {
+	return resolveSelected(c, c.Data.Resolved.Channel)
+}
+
+// Returns the users and roles selected in a mentionable select menu.
+func (c *Context) Mentionables() ([]common.User, []common.Role) {
+	return c.Users(), c.Roles()
+}
+
+// Returns the resolved objects of the selected IDs, in the order they were selected.
+func resolveSelected[T any](c *Context, resolve func(common.Snowflake) (T, bool)) []T {
+
+	var values []T
+	for _, id := range c.Snowflakes() {
+		if value, ok := resolve(id); ok {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
+
+//// Invocation
+
+// Returns the user who triggered the interaction, in guilds and in DMs.
+func (c *responder) Invoker() common.User {
+
+	if c.Interaction.Member != nil && c.Interaction.Member.User != nil {
+		return *c.Interaction.Member.User
+	}
+
+	if c.Interaction.User != nil {
+		return *c.Interaction.User
+	}
+
+	return common.User{}
+}
+
+// Returns the ID of the guild the interaction was triggered in, or zero in DMs.
+func (c *responder) GuildId() common.Snowflake {
+
+	if c.Interaction.GuildId == nil {
+		return 0
+	}
+
+	return *c.Interaction.GuildId
+}
+
+// Returns the ID of the channel the interaction was triggered in.
+func (c *responder) ChannelId() common.Snowflake {
+
+	if c.Interaction.ChannelId == nil {
+		return 0
+	}
+
+	return *c.Interaction.ChannelId
+}
+
+//// Responses
+
+// Sends a response to the interaction.
+func (c *responder) Respond(response common.InteractionResponse) error {
+	return c.App.RespondToInteraction(c.Interaction.Id, c.Interaction.Token, response)
+}
+
+// Responds with a new message.
+func (c *responder) Reply(content string) error {
+	return c.Respond(common.InteractionResponse{
+		Type: common.ChannelMessageWithSourceInteractionCallbackType,
+		Data: &common.InteractionCallbackData{Content: &content},
+	})
+}
+
+// Responds with a new message only the invoking user can see.
+func (c *responder) ReplyEphemeral(content string) error {
+
+	flags := common.EphemeralMessageFlag
+
+	return c.Respond(common.InteractionResponse{
+		Type: common.ChannelMessageWithSourceInteractionCallbackType,
+		Data: &common.InteractionCallbackData{Content: &content, Flags: &flags},
+	})
+}
+
+// Responds by editing the message the component, or the component that opened the modal, is
+// attached to.
+func (c *responder) Update(data common.InteractionCallbackData) error {
+	return c.Respond(common.InteractionResponse{
+		Type: common.UpdateMessageInteractionCallbackType,
+		Data: &data,
+	})
+}
+
+// Acknowledges the interaction without changing the message the component is attached to; it can be
+// edited later.
+func (c *responder) DeferUpdate() error {
+	return c.Respond(common.InteractionResponse{Type: common.DeferredUpdateMessageInteractionCallbackType})
+}
+
+// Responds with a modal, then passes its submission by the same user to handler. See ShowModal.
+func (c *Context) ShowModal(modal *ModalBuilder, handler ModalHandler) error {
+	return ShowModal(c.App, c.Interaction, modal, handler)
+}
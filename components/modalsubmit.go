@@ -0,0 +1,362 @@
+package components
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"brandenly.com/go/packages/discord-bot/common"
+	"brandenly.com/go/packages/discord-bot/discord"
+	"brandenly.com/go/packages/discord-bot/gateway"
+)
+
+// How long ShowModal waits for a submission. Interaction tokens, and so responses to the submission,
+// are valid for 15 minutes.
+const ModalTimeout = 15 * time.Minute
+
+// Struct tag key naming the text input a field is decoded from.
+const ValueTag = "discord"
+
+// Invoked for a submitted modal.
+type ModalHandler func(ctx *ModalContext) error
+
+// A submitted modal, with the values of its text inputs by custom_id. Inputs left empty are treated
+// as not filled in.
+type ModalContext struct {
+	context.Context
+	responder
+	Data   *gateway.ModalSubmitData // Decoded modal submit data of the interaction
+	Params map[string]string        // Parameters captured from the modal's custom_id by the handler's pattern; nil for ShowModal handlers
+
+	values map[string]string
+}
+
+func newModalContext(ctx context.Context, a *discord.App, interaction *gateway.InteractionCreate, data *gateway.ModalSubmitData, params map[string]string) *ModalContext {
+	return &ModalContext{
+		Context:   ctx,
+		responder: responder{App: a, Interaction: interaction},
+		Data:      data,
+		Params:    params,
+		values:    data.Values(),
+	}
+}
+
+// Returns a parameter captured from the custom_id.
+func (c *ModalContext) Param(name string) string {
+	return c.Params[name]
+}
+
+//// Values
+
+// Returns the submitted values by text input custom_id.
+func (c *ModalContext) Values() map[string]string {
+
+	values := make(map[string]string, len(c.values))
+	for customId, value := range c.values {
+		values[customId] = value
+	}
+
+	return values
+}
+
+// Returns the value of a text input, or "" if it was left empty.
+func (c *ModalContext) Value(customId string) string {
+	return c.values[customId]
+}
+
+// Reports whether a text input was filled in.
+func (c *ModalContext) Has(customId string) bool {
+	return strings.TrimSpace(c.values[customId]) != ""
+}
+
+// Parses the value of a text input as an integer.
+func (c *ModalContext) Int(customId string) (int64, error) {
+
+	n, err := strconv.ParseInt(strings.TrimSpace(c.values[customId]), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s is not a whole number", customId)
+	}
+
+	return n, nil
+}
+
+// Parses the value of a text input as a number.
+func (c *ModalContext) Number(customId string) (float64, error) {
+
+	n, err := strconv.ParseFloat(strings.TrimSpace(c.values[customId]), 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s is not a number", customId)
+	}
+
+	return n, nil
+}
+
+// Parses the value of a text input as a boolean, accepting true/false, yes/no, y/n, on/off and 1/0.
+func (c *ModalContext) Bool(customId string) (bool, error) {
+
+	switch strings.ToLower(strings.TrimSpace(c.values[customId])) {
+	case "true", "yes", "y", "on", "1":
+		return true, nil
+	case "false", "no", "n", "off", "0":
+		return false, nil
+	}
+
+	return false, fmt.Errorf("%s is not yes or no", customId)
+}
+
+// Parses the value of a text input as an ID.
+func (c *ModalContext) Snowflake(customId string) (common.Snowflake, error) {
+
+	id, err := common.ParseSnowflake(strings.TrimSpace(c.values[customId]))
+	if err != nil {
+		return 0, fmt.Errorf("%s is not an ID", customId)
+	}
+
+	return id, nil
+}
+
+// Decodes the submitted values into the struct dst points to. Each field tagged
+// `discord:"custom_id"` receives the value of that text input, parsed by the field's type: strings,
+// integers, floats, bools or common.Snowflake, or pointers to these that are left nil when the input
+// is empty. A ",required" suffix makes an empty input an error.
+func (c *ModalContext) Decode(dst any) error {
+
+	target := reflect.ValueOf(dst)
+	if target.Kind() != reflect.Pointer || target.IsNil() || target.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("decode target must be a non-nil pointer to a struct, got %T", dst)
+	}
+
+	for _, field := range reflect.VisibleFields(target.Elem().Type()) {
+
+		tag, ok := field.Tag.Lookup(ValueTag)
+		if !ok || tag == "-" || !field.IsExported() {
+			continue
+		}
+
+		customId, settings, _ := strings.Cut(tag, ",")
+		if customId == "" {
+			customId = strings.ToLower(field.Name)
+		}
+
+		if !c.Has(customId) {
+			if settings == "required" {
+				return fmt.Errorf("modal %q: %s is required", c.Data.CustomId, customId)
+			}
+			continue
+		}
+
+		value := target.Elem().FieldByIndex(field.Index)
+		if value.Kind() == reflect.Pointer {
+			value.Set(reflect.New(value.Type().Elem()))
+			value = value.Elem()
+		}
+
+		if err := c.decodeValue(customId, value); err != nil {
+			return fmt.Errorf("modal %q: %w", c.Data.CustomId, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *ModalContext) decodeValue(customId string, value reflect.Value) error {
+
+	if value.Type() == reflect.TypeFor[common.Snowflake]() {
+		id, err := c.Snowflake(customId)
+		if err != nil {
+			return err
+		}
+		value.SetUint(uint64(id))
+		return nil
+	}
+
+	switch value.Kind() {
+
+	case reflect.String:
+		value.SetString(c.Value(customId))
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := c.Int(customId)
+		if err != nil {
+			return err
+		}
+		if value.OverflowInt(n) {
+			return fmt.Errorf("%s is out of range", customId)
+		}
+		value.SetInt(n)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := c.Int(customId)
+		if err != nil {
+			return err
+		}
+		if n < 0 || value.OverflowUint(uint64(n)) {
+			return fmt.Errorf("%s is out of range", customId)
+		}
+		value.SetUint(uint64(n))
+
+	case reflect.Float32, reflect.Float64:
+		n, err := c.Number(customId)
+		if err != nil {
+			return err
+		}
+		value.SetFloat(n)
+
+	case reflect.Bool:
+		b, err := c.Bool(customId)
+		if err != nil {
+			return err
+		}
+		value.SetBool(b)
+
+	default:
+		return fmt.Errorf("unsupported field type %s", value.Type())
+	}
+
+	return nil
+}
+
+//// Showing Modals
+
+// How long a submission already passed to its ShowModal handler is remembered, so that it isn't
+// routed again by a Router seeing the same interaction.
+const servedModalRetention = time.Minute
+
+// Modals shown by ShowModal for one application that are waiting to be submitted.
+type pendingModals struct {
+	register sync.Once
+	mu       sync.Mutex
+	waiting  map[pendingModalKey]pendingModal
+	served   map[common.Snowflake]time.Time // When each submission passed to its handler was served, by interaction ID
+}
+
+type pendingModalKey struct {
+	customId string
+	userId   common.Snowflake
+}
+
+type pendingModal struct {
+	handler ModalHandler
+	expires time.Time
+}
+
+var pending sync.Map // *discord.App -> *pendingModals
+
+// Returns the modals waiting to be submitted to an application, subscribing a single handler that
+// serves them the first time.
+func pendingModalsOf(a *discord.App) *pendingModals {
+
+	value, _ := pending.LoadOrStore(a, &pendingModals{
+		waiting: map[pendingModalKey]pendingModal{},
+		served:  map[common.Snowflake]time.Time{},
+	})
+	modals := value.(*pendingModals)
+
+	modals.register.Do(func() {
+		discord.On(a, func(ctx context.Context, a *discord.App, interaction *gateway.InteractionCreate) error {
+
+			if interaction.Type != gateway.InteractionType["MODAL_SUBMIT"] || interaction.Data == nil {
+				return nil
+			}
+
+			var data gateway.ModalSubmitData
+			if err := json.Unmarshal(*interaction.Data, &data); err != nil {
+				return nil
+			}
+
+			_, err := modals.serve(ctx, a, interaction, &data)
+			return err
+		})
+	})
+
+	return modals
+}
+
+// Passes a submission to the handler of the modal it answers, reporting whether it answers one. The
+// application's handler and every Router consult the pending modals, but a submission is only served
+// once.
+func (m *pendingModals) serve(ctx context.Context, a *discord.App, interaction *gateway.InteractionCreate, data *gateway.ModalSubmitData) (bool, error) {
+
+	now := time.Now()
+
+	m.mu.Lock()
+	m.expire(now)
+
+	if _, ok := m.served[interaction.Id]; ok {
+		m.mu.Unlock()
+		return true, nil
+	}
+
+	key := pendingModalKey{customId: data.CustomId, userId: interactionUser(interaction).Id}
+	modal, ok := m.waiting[key]
+	if !ok {
+		m.mu.Unlock()
+		return false, nil
+	}
+
+	delete(m.waiting, key)
+	m.served[interaction.Id] = now
+	m.mu.Unlock()
+
+	return true, modal.handler(newModalContext(ctx, a, interaction, data, nil))
+}
+
+// Forgets modals that were not submitted in time, and submissions served a while ago.
+func (m *pendingModals) expire(now time.Time) {
+
+	for key, modal := range m.waiting {
+		if now.After(modal.expires) {
+			delete(m.waiting, key)
+		}
+	}
+
+	for id, served := range m.served {
+		if now.Sub(served) > servedModalRetention {
+			delete(m.served, id)
+		}
+	}
+}
+
+// Responds to interaction with modal, then passes its submission by the same user to handler.
+// Rather than blocking until the modal is submitted, which would hold up dispatch, the modal is kept
+// with the others waiting to be submitted, until it is submitted or for ModalTimeout, and showing
+// the same modal to the same user again replaces it. Its submission is not routed to Router modal
+// patterns.
+func ShowModal(a *discord.App, interaction *gateway.InteractionCreate, modal *ModalBuilder, handler ModalHandler) error {
+
+	data, err := modal.Build()
+	if err != nil {
+		return err
+	}
+
+	modals := pendingModalsOf(a)
+	key := pendingModalKey{customId: *data.CustomId, userId: interactionUser(interaction).Id}
+
+	modals.mu.Lock()
+	modals.expire(time.Now())
+	modals.waiting[key] = pendingModal{handler: handler, expires: time.Now().Add(ModalTimeout)}
+	modals.mu.Unlock()
+
+	err = a.RespondToInteraction(interaction.Id, interaction.Token, common.InteractionResponse{
+		Type: common.ModalInteractionCallbackType,
+		Data: &data,
+	})
+	if err != nil {
+		modals.mu.Lock()
+		delete(modals.waiting, key)
+		modals.mu.Unlock()
+		return err
+	}
+
+	return nil
+}
+
+// Returns the user who triggered an interaction, in guilds and in DMs.
+func interactionUser(interaction *gateway.InteractionCreate) common.User {
+	return (&responder{Interaction: interaction}).Invoker()
+}
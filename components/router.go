@@ -0,0 +1,273 @@
+package components
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"brandenly.com/go/packages/discord-bot/discord"
+	"brandenly.com/go/packages/discord-bot/gateway"
+)
+
+// Maximum length of a component's custom_id
+const MaxCustomIdLength = 100
+
+// Separates the segments of a custom_id pattern
+const Separator = ":"
+
+// Invoked for a component interaction whose custom_id matches the handler's pattern.
+type Handler func(ctx *Context) error
+
+// Routes message component and modal submit interactions to handlers by custom_id. Patterns are
+// made of segments separated by ":", where a segment in braces, such as "{id}" in
+// "ticket:close:{id}", captures the corresponding custom_id segment as a parameter and a final
+// "{name...}" captures the rest of the custom_id. When several patterns match, the one with the most
+// literal segments wins.
+type Router struct {
+	mu         sync.RWMutex
+	components []route[Handler]
+	modals     []route[ModalHandler]
+}
+
+type route[H any] struct {
+	pattern  string
+	segments []segment
+	literals int
+	handler  H
+}
+
+type segment struct {
+	value string // Literal value, or parameter name
+	param bool   // Whether the segment captures a parameter
+	rest  bool   // Whether the parameter captures the rest of the custom_id
+}
+
+// Returns an empty router.
+func NewRouter() *Router {
+	return &Router{}
+}
+
+// Adds a handler for components whose custom_id matches pattern. A pattern replaces an earlier
+// identical one.
+func (r *Router) Add(pattern string, handler Handler) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return addRoute(&r.components, pattern, handler)
+}
+
+// Adds a handler for submitted modals whose custom_id matches pattern. A pattern replaces an earlier
+// identical one.
+func (r *Router) AddModal(pattern string, handler ModalHandler) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return addRoute(&r.modals, pattern, handler)
+}
+
+// Returns the component handler for a custom_id along with the parameters captured by its pattern.
+func (r *Router) Match(customId string) (Handler, map[string]string, bool) {
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return matchRoute(r.components, customId)
+}
+
+// Returns the modal handler for a custom_id along with the parameters captured by its pattern.
+func (r *Router) MatchModal(customId string) (ModalHandler, map[string]string, bool) {
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return matchRoute(r.modals, customId)
+}
+
+// Subscribes the router to the application's INTERACTION_CREATE events.
+func (r *Router) Register(a *discord.App) (unsubscribe func()) {
+	return discord.On(a, r.Handle)
+}
+
+// Invokes the handler matching the custom_id of a component or modal submit interaction. Other
+// interactions are ignored, as are custom_ids no pattern matches so that several routers can share
+// an application.
+func (r *Router) Handle(ctx context.Context, a *discord.App, interaction *gateway.InteractionCreate) error {
+
+	if interaction.Data == nil {
+		return nil
+	}
+
+	switch interaction.Type {
+
+	case gateway.InteractionType["MESSAGE_COMPONENT"]:
+
+		var data gateway.MessageComponentData
+		if err := json.Unmarshal(*interaction.Data, &data); err != nil {
+			return fmt.Errorf("unable to unmarshal message component data: %w", err)
+		}
+
+		handler, params, ok := r.Match(data.CustomId)
+		if !ok {
+			return nil
+		}
+
+		return handler(&Context{
+			Context:   ctx,
+			responder: responder{App: a, Interaction: interaction},
+			Data:      &data,
+			Params:    params,
+		})
+
+	case gateway.InteractionType["MODAL_SUBMIT"]:
+
+		var data gateway.ModalSubmitData
+		if err := json.Unmarshal(*interaction.Data, &data); err != nil {
+			return fmt.Errorf("unable to unmarshal modal submit data: %w", err)
+		}
+
+		// Submissions of modals shown by ShowModal go to their own handler
+		if modals, ok := pending.Load(a); ok {
+			if served, err := modals.(*pendingModals).serve(ctx, a, interaction, &data); served {
+				return err
+			}
+		}
+
+		handler, params, ok := r.MatchModal(data.CustomId)
+		if !ok {
+			return nil
+		}
+
+		return handler(newModalContext(ctx, a, interaction, &data, params))
+	}
+
+	return nil
+}
+
+//// Patterns
+
+func parsePattern(pattern string) ([]segment, error) {
+
+	if pattern == "" || len(pattern) > MaxCustomIdLength {
+		return nil, fmt.Errorf("custom_id pattern %q must be 1-%d characters", pattern, MaxCustomIdLength)
+	}
+
+	parts := strings.Split(pattern, Separator)
+	segments := make([]segment, 0, len(parts))
+	names := map[string]bool{}
+
+	for i, part := range parts {
+
+		if !strings.HasPrefix(part, "{") || !strings.HasSuffix(part, "}") {
+			if strings.ContainsAny(part, "{}") {
+				return nil, fmt.Errorf("custom_id pattern %q: invalid segment %q", pattern, part)
+			}
+			segments = append(segments, segment{value: part})
+			continue
+		}
+
+		name, rest := strings.CutSuffix(part[1:len(part)-1], "...")
+		if name == "" || strings.ContainsAny(name, "{}") {
+			return nil, fmt.Errorf("custom_id pattern %q: invalid parameter %q", pattern, part)
+		}
+
+		if rest && i != len(parts)-1 {
+			return nil, fmt.Errorf("custom_id pattern %q: %q must be the last segment", pattern, part)
+		}
+
+		if names[name] {
+			return nil, fmt.Errorf("custom_id pattern %q: duplicate parameter %q", pattern, name)
+		}
+		names[name] = true
+
+		segments = append(segments, segment{value: name, param: true, rest: rest})
+	}
+
+	return segments, nil
+}
+
+func addRoute[H any](routes *[]route[H], pattern string, handler H) error {
+
+	segments, err := parsePattern(pattern)
+	if err != nil {
+		return err
+	}
+
+	added := route[H]{pattern: pattern, segments: segments, handler: handler}
+	for _, segment := range segments {
+		if !segment.param {
+			added.literals++
+		}
+	}
+
+	for i, existing := range *routes {
+		if existing.pattern == pattern {
+			(*routes)[i] = added
+			return nil
+		}
+	}
+
+	*routes = append(*routes, added)
+	return nil
+}
+
+func matchRoute[H any](routes []route[H], customId string) (H, map[string]string, bool) {
+
+	var best *route[H]
+	var bestParams map[string]string
+
+	for i := range routes {
+		if params, ok := routes[i].match(customId); ok && (best == nil || routes[i].literals > best.literals) {
+			best = &routes[i]
+			bestParams = params
+		}
+	}
+
+	if best == nil {
+		var none H
+		return none, nil, false
+	}
+
+	return best.handler, bestParams, true
+}
+
+func (r *route[H]) match(customId string) (map[string]string, bool) {
+
+	params := map[string]string{}
+	remaining := customId
+
+	for i, segment := range r.segments {
+
+		if segment.rest {
+			params[segment.value] = remaining
+			return params, true
+		}
+
+		var part string
+		last := i == len(r.segments)-1
+
+		if last {
+			part = remaining
+			if strings.Contains(part, Separator) {
+				return nil, false
+			}
+		} else {
+			var found bool
+			part, remaining, found = strings.Cut(remaining, Separator)
+			if !found {
+				return nil, false
+			}
+		}
+
+		if segment.param {
+			params[segment.value] = part
+		} else if part != segment.value {
+			return nil, false
+		}
+	}
+
+	return params, true
+}
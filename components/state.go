@@ -0,0 +1,177 @@
+package components
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"unicode/utf8"
+)
+
+// Bytes of the HMAC-SHA256 signature kept in signed state
+const SignatureSize = 8
+
+// Returned when embedded state can't be decoded or its signature doesn't match.
+var ErrInvalidState = errors.New("invalid component state")
+
+// Encodes small values into custom_id segments. Structs are encoded as a JSON array of their exported
+// field values, without field names, and the result is base64url encoded so it contains no ":".
+// When created with a key, state is signed so it can't be forged or altered by clients; a signature
+// adds 11 characters.
+type Codec struct {
+	key []byte
+}
+
+// Returns a codec that signs state with key, or an unsigned codec when key is empty.
+func NewCodec(key []byte) *Codec {
+	return &Codec{key: key}
+}
+
+// Encodes v as a custom_id segment.
+func (c *Codec) Encode(v any) (string, error) {
+
+	payload, err := json.Marshal(compact(reflect.ValueOf(v)))
+	if err != nil {
+		return "", fmt.Errorf("unable to encode component state: %w", err)
+	}
+
+	if len(c.key) > 0 {
+		payload = append(c.sign(payload), payload...)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(payload), nil
+}
+
+// Decodes a segment produced by Encode into the value dst points to.
+func (c *Codec) Decode(segment string, dst any) error {
+
+	payload, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return ErrInvalidState
+	}
+
+	if len(c.key) > 0 {
+
+		if len(payload) < SignatureSize || !hmac.Equal(payload[:SignatureSize], c.sign(payload[SignatureSize:])) {
+			return ErrInvalidState
+		}
+
+		payload = payload[SignatureSize:]
+	}
+
+	target := reflect.ValueOf(dst)
+	if target.Kind() != reflect.Pointer || target.IsNil() {
+		return fmt.Errorf("decode target must be a non-nil pointer, got %T", dst)
+	}
+
+	if err := expand(payload, target.Elem()); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidState, err)
+	}
+
+	return nil
+}
+
+// Returns a custom_id made of prefix and v's encoding, such as "ticket:close:" and a ticket struct.
+// Fails if the custom_id would exceed MaxCustomIdLength.
+func (c *Codec) CustomId(prefix string, v any) (string, error) {
+
+	segment, err := c.Encode(v)
+	if err != nil {
+		return "", err
+	}
+
+	customId := prefix + segment
+	if n := utf8.RuneCountInString(customId); n > MaxCustomIdLength {
+		return "", fmt.Errorf("custom_id is %d characters, more than the maximum of %d", n, MaxCustomIdLength)
+	}
+
+	return customId, nil
+}
+
+func (c *Codec) sign(payload []byte) []byte {
+
+	mac := hmac.New(sha256.New, c.key)
+	mac.Write(payload)
+	return mac.Sum(nil)[:SignatureSize]
+}
+
+// Replaces structs with arrays of their exported field values.
+func compact(v reflect.Value) any {
+
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct || implementsMarshaler(v.Type()) {
+		if !v.IsValid() {
+			return nil
+		}
+		return v.Interface()
+	}
+
+	var fields []any
+	for _, field := range reflect.VisibleFields(v.Type()) {
+		if field.IsExported() && !field.Anonymous {
+			fields = append(fields, compact(v.FieldByIndex(field.Index)))
+		}
+	}
+
+	return fields
+}
+
+// Decodes data produced from compact into v.
+func expand(data []byte, v reflect.Value) error {
+
+	if v.Kind() == reflect.Pointer {
+
+		if string(data) == "null" {
+			v.Set(reflect.Zero(v.Type()))
+			return nil
+		}
+
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+
+		return expand(data, v.Elem())
+	}
+
+	if v.Kind() != reflect.Struct || implementsMarshaler(v.Type()) {
+		return json.Unmarshal(data, v.Addr().Interface())
+	}
+
+	var values []json.RawMessage
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+
+	i := 0
+	for _, field := range reflect.VisibleFields(v.Type()) {
+
+		if !field.IsExported() || field.Anonymous {
+			continue
+		}
+
+		if i >= len(values) {
+			break
+		}
+
+		if err := expand(values[i], v.FieldByIndex(field.Index)); err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		i++
+	}
+
+	return nil
+}
+
+// Reports whether t has its own JSON encoding, such as time.Time, and so is kept as is.
+func implementsMarshaler(t reflect.Type) bool {
+	return t.Implements(reflect.TypeFor[json.Marshaler]()) || reflect.PointerTo(t).Implements(reflect.TypeFor[json.Marshaler]())
+}
@@ -0,0 +1,220 @@
+package cooldown
+
+import (
+	"sync"
+	"time"
+
+	"brandenly.com/go/packages/discord-bot/common"
+)
+
+// What uses of a command are counted together.
+type Scope uint8
+
+const ( // Scopes
+	UserScope    Scope = iota // Each user, wherever they use the command
+	MemberScope               // Each user in each guild; each user in DMs
+	ChannelScope              // Each channel
+	GuildScope                // Each guild; each DM channel in DMs
+)
+
+// Scopes by name.
+var Scopes map[string]Scope = map[string]Scope{
+	"USER":    UserScope,
+	"MEMBER":  MemberScope,
+	"CHANNEL": ChannelScope,
+	"GUILD":   GuildScope,
+}
+
+// Who is using a command, and where.
+type Invocation struct {
+	UserId    common.Snowflake   // User invoking the command
+	GuildId   common.Snowflake   // Guild the command is invoked in, or zero in DMs
+	ChannelId common.Snowflake   // Channel the command is invoked in
+	Roles     []common.Snowflake // Roles of the invoking member, if invoked in a guild
+}
+
+// Identifies the uses counted together within a scope.
+type key struct {
+	scope common.Snowflake
+	id    common.Snowflake
+}
+
+// Returns the key of the uses inv is counted with.
+func (s Scope) key(inv Invocation) key {
+
+	switch s {
+	case MemberScope:
+		return key{scope: inv.GuildId, id: inv.UserId}
+	case ChannelScope:
+		return key{id: inv.ChannelId}
+	case GuildScope:
+		if inv.GuildId == 0 {
+			return key{id: inv.ChannelId}
+		}
+		return key{id: inv.GuildId}
+	}
+
+	return key{id: inv.UserId}
+}
+
+//// Cooldowns
+
+// Limits a command to Uses per Per in each scope. Uses are spread evenly over Per, but up to Burst
+// can be made back to back after a quiet period; a burst then takes Per / Uses per use to recover.
+type Cooldown struct {
+	Scope Scope         // What uses are counted together
+	Uses  int           // Uses allowed per Per
+	Per   time.Duration // Period Uses are allowed in
+	Burst int           // Uses that can be made back to back, defaults to Uses
+
+	mu    sync.Mutex
+	next  map[key]time.Time // When each key's bucket is next full, as the theoretical arrival time of GCRA
+	sweep time.Time
+}
+
+// Returns a cooldown allowing uses per period in each scope, all of which can be made back to back.
+func New(scope Scope, uses int, per time.Duration) *Cooldown {
+	return &Cooldown{Scope: scope, Uses: uses, Per: per}
+}
+
+// Sets how many uses can be made back to back, and returns the cooldown.
+func (c *Cooldown) WithBurst(burst int) *Cooldown {
+	c.Burst = burst
+	return c
+}
+
+// Returns the time it takes to recover a single use.
+func (c *Cooldown) interval() time.Duration {
+	return c.Per / time.Duration(max(c.Uses, 1))
+}
+
+func (c *Cooldown) burst() int {
+
+	if c.Burst > 0 {
+		return c.Burst
+	}
+
+	return max(c.Uses, 1)
+}
+
+// Takes a use for inv at now, or returns how long until one is available.
+func (c *Cooldown) Take(inv Invocation, now time.Time) (retryAfter time.Duration, ok bool) {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.cleanup(now)
+
+	k := c.Scope.key(inv)
+	interval := c.interval()
+
+	next := c.next[k]
+	if next.Before(now) {
+		next = now
+	}
+
+	// A use is allowed while the bucket is less than burst uses from full
+	if allowedAt := next.Add(-interval * time.Duration(c.burst()-1)); allowedAt.After(now) {
+		return allowedAt.Sub(now), false
+	}
+
+	if c.next == nil {
+		c.next = map[key]time.Time{}
+	}
+	c.next[k] = next.Add(interval)
+
+	return 0, true
+}
+
+// Gives back a use taken for inv, such as when another limit refused the invocation.
+func (c *Cooldown) refund(inv Invocation) {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	k := c.Scope.key(inv)
+	if next, ok := c.next[k]; ok {
+		c.next[k] = next.Add(-c.interval())
+	}
+}
+
+// Forgets the uses counted with inv, so the command can be used again right away.
+func (c *Cooldown) Reset(inv Invocation) {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.next, c.Scope.key(inv))
+}
+
+// Forgets full buckets, at most once per period.
+func (c *Cooldown) cleanup(now time.Time) {
+
+	if now.Sub(c.sweep) < c.Per {
+		return
+	}
+
+	c.sweep = now
+	for k, next := range c.next {
+		if !next.After(now) {
+			delete(c.next, k)
+		}
+	}
+}
+
+//// Concurrency
+
+// Limits how many uses of a command can run at once in each scope, such as a single export per
+// guild.
+type Concurrency struct {
+	Scope Scope // What uses are counted together
+	Max   int   // Uses that can run at once
+
+	mu      sync.Mutex
+	running map[key]int
+}
+
+// Returns a limit of max uses running at once in each scope.
+func NewConcurrency(scope Scope, max int) *Concurrency {
+	return &Concurrency{Scope: scope, Max: max}
+}
+
+// Starts a use for inv, returning a func to call once it has finished, or false if Max uses are
+// already running.
+func (c *Concurrency) Acquire(inv Invocation) (release func(), ok bool) {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	k := c.Scope.key(inv)
+	if c.running[k] >= max(c.Max, 1) {
+		return nil, false
+	}
+
+	if c.running == nil {
+		c.running = map[key]int{}
+	}
+	c.running[k]++
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+
+			c.mu.Lock()
+			defer c.mu.Unlock()
+
+			if c.running[k]--; c.running[k] <= 0 {
+				delete(c.running, k)
+			}
+		})
+	}, true
+}
+
+// Returns how many uses are running for inv's scope.
+func (c *Concurrency) Running(inv Invocation) int {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.running[c.Scope.key(inv)]
+}
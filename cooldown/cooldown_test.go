@@ -0,0 +1,284 @@
+package cooldown
+
+import (
+	"testing"
+	"time"
+
+	"brandenly.com/go/packages/discord-bot/common"
+)
+
+func TestCooldownTake(t *testing.T) {
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	inv := Invocation{UserId: 1, GuildId: 2, ChannelId: 3}
+
+	type take struct {
+		at         time.Duration // Since start
+		ok         bool
+		retryAfter time.Duration
+	}
+
+	tests := []struct {
+		name     string
+		cooldown func() *Cooldown
+		takes    []take
+	}{
+		{
+			name:     "burst of uses then one per interval",
+			cooldown: func() *Cooldown { return New(UserScope, 3, 3*time.Second) },
+			takes: []take{
+				{at: 0, ok: true},
+				{at: 0, ok: true},
+				{at: 0, ok: true},
+				{at: 0, ok: false, retryAfter: time.Second},
+				{at: 999 * time.Millisecond, ok: false, retryAfter: time.Millisecond},
+				{at: time.Second, ok: true},
+				{at: time.Second, ok: false, retryAfter: time.Second},
+			},
+		},
+		{
+			name:     "full again after the period",
+			cooldown: func() *Cooldown { return New(UserScope, 2, 2*time.Second) },
+			takes: []take{
+				{at: 0, ok: true},
+				{at: 0, ok: true},
+				{at: 2 * time.Second, ok: true},
+				{at: 2 * time.Second, ok: true},
+				{at: 2 * time.Second, ok: false, retryAfter: time.Second},
+			},
+		},
+		{
+			name:     "burst of one spreads uses evenly",
+			cooldown: func() *Cooldown { return New(UserScope, 3, 3*time.Second).WithBurst(1) },
+			takes: []take{
+				{at: 0, ok: true},
+				{at: 0, ok: false, retryAfter: time.Second},
+				{at: 500 * time.Millisecond, ok: false, retryAfter: 500 * time.Millisecond},
+				{at: time.Second, ok: true},
+				{at: 2 * time.Second, ok: true},
+			},
+		},
+		{
+			name:     "burst larger than uses",
+			cooldown: func() *Cooldown { return New(UserScope, 1, time.Second).WithBurst(2) },
+			takes: []take{
+				{at: 0, ok: true},
+				{at: 0, ok: true},
+				{at: 0, ok: false, retryAfter: time.Second},
+				{at: time.Second, ok: true},
+				{at: time.Second, ok: false, retryAfter: time.Second},
+			},
+		},
+		{
+			name:     "zero uses allows one per period",
+			cooldown: func() *Cooldown { return New(UserScope, 0, time.Second) },
+			takes: []take{
+				{at: 0, ok: true},
+				{at: 0, ok: false, retryAfter: time.Second},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			cooldown := test.cooldown()
+			for i, take := range test.takes {
+
+				retryAfter, ok := cooldown.Take(inv, start.Add(take.at))
+				if ok != take.ok || retryAfter != take.retryAfter {
+					t.Fatalf("take %d at %s = (%s, %v), want (%s, %v)", i, take.at, retryAfter, ok, take.retryAfter, take.ok)
+				}
+			}
+		})
+	}
+}
+
+func TestCooldownRefund(t *testing.T) {
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	inv := Invocation{UserId: 1}
+
+	cooldown := New(UserScope, 2, 2*time.Second)
+
+	// Refunding a key that was never taken does nothing
+	cooldown.refund(inv)
+	for i := range 2 {
+		if _, ok := cooldown.Take(inv, now); !ok {
+			t.Fatalf("take %d refused", i)
+		}
+	}
+
+	if _, ok := cooldown.Take(inv, now); ok {
+		t.Fatal("take beyond the burst allowed")
+	}
+
+	// A refund at the edge of the burst gives back exactly one use
+	cooldown.refund(inv)
+	if _, ok := cooldown.Take(inv, now); !ok {
+		t.Fatal("take after refund refused")
+	}
+	if retryAfter, ok := cooldown.Take(inv, now); ok || retryAfter != time.Second {
+		t.Fatalf("second take after refund = (%s, %v), want (1s, false)", retryAfter, ok)
+	}
+
+	// Refunds never build up more than a full bucket
+	cooldown.refund(inv)
+	cooldown.refund(inv)
+	cooldown.refund(inv)
+	for i := range 2 {
+		if _, ok := cooldown.Take(inv, now); !ok {
+			t.Fatalf("take %d after refunds refused", i)
+		}
+	}
+	if _, ok := cooldown.Take(inv, now); ok {
+		t.Fatal("refunds allowed more than a full burst")
+	}
+}
+
+func TestCooldownScopes(t *testing.T) {
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		scope  Scope
+		first  Invocation
+		second Invocation
+		shared bool
+	}{
+		{UserScope, Invocation{UserId: 1, GuildId: 1}, Invocation{UserId: 1, GuildId: 2}, true},
+		{UserScope, Invocation{UserId: 1}, Invocation{UserId: 2}, false},
+		{MemberScope, Invocation{UserId: 1, GuildId: 1}, Invocation{UserId: 1, GuildId: 2}, false},
+		{MemberScope, Invocation{UserId: 1, GuildId: 1, ChannelId: 1}, Invocation{UserId: 1, GuildId: 1, ChannelId: 2}, true},
+		{ChannelScope, Invocation{UserId: 1, ChannelId: 1}, Invocation{UserId: 2, ChannelId: 1}, true},
+		{GuildScope, Invocation{UserId: 1, GuildId: 1, ChannelId: 1}, Invocation{UserId: 2, GuildId: 1, ChannelId: 2}, true},
+		{GuildScope, Invocation{UserId: 1, ChannelId: 1}, Invocation{UserId: 1, ChannelId: 2}, false}, // DM channels count as guilds
+	}
+
+	for i, test := range tests {
+
+		cooldown := New(test.scope, 1, time.Minute)
+		cooldown.Take(test.first, now)
+
+		if _, ok := cooldown.Take(test.second, now); ok == test.shared {
+			t.Errorf("case %d: second invocation allowed = %v, want %v", i, ok, !test.shared)
+		}
+	}
+}
+
+func TestLimitsAcquire(t *testing.T) {
+
+	inv := Invocation{UserId: 1, GuildId: 2, Roles: nil}
+
+	t.Run("refunds earlier cooldowns when a later one refuses", func(t *testing.T) {
+
+		perUser := New(UserScope, 3, time.Minute)
+		perGuild := New(GuildScope, 1, time.Minute)
+		limits := With(perUser, perGuild)
+
+		release, err := limits.Acquire(inv)
+		if err != nil {
+			t.Fatal(err)
+		}
+		release()
+
+		for range 3 {
+			if _, err := limits.Acquire(inv); err == nil {
+				t.Fatal("acquired past the guild cooldown")
+			}
+		}
+
+		// Only the successful acquire counted towards the user cooldown
+		now := time.Now()
+		for i := range 2 {
+			if _, ok := perUser.Take(inv, now); !ok {
+				t.Fatalf("user take %d refused", i)
+			}
+		}
+		if _, ok := perUser.Take(inv, now); ok {
+			t.Fatal("refused acquires were counted")
+		}
+	})
+
+	t.Run("refused by concurrency without counting cooldowns", func(t *testing.T) {
+
+		perUser := New(UserScope, 2, time.Minute)
+		limits := With(perUser).WithConcurrency(NewConcurrency(UserScope, 1))
+
+		release, err := limits.Acquire(inv)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = limits.Acquire(inv)
+		if limited, ok := err.(*Error); !ok || limited.RetryAfter != 0 || limited.Message != DefaultBusyMessage {
+			t.Fatalf("second acquire error = %v, want busy", err)
+		}
+
+		release()
+		release() // Releasing twice frees a single slot
+
+		if _, err := limits.Acquire(inv); err != nil {
+			t.Fatalf("acquire after release: %v", err)
+		}
+	})
+
+	t.Run("cooldown message", func(t *testing.T) {
+
+		limits := With(New(UserScope, 1, 90*time.Second))
+		limits.CooldownMessage = "Wait {retry}"
+
+		limits.Acquire(inv)
+		_, err := limits.Acquire(inv)
+
+		limited, ok := err.(*Error)
+		if !ok || limited.Message != "Wait 1m30s" {
+			t.Fatalf("error = %v, want message %q", err, "Wait 1m30s")
+		}
+	})
+
+	t.Run("bypass", func(t *testing.T) {
+
+		bypass := NewBypass(7)
+		bypass.SetRoles(2, 9)
+		limits := With(New(UserScope, 1, time.Minute)).WithBypass(bypass)
+
+		for _, bypassed := range []Invocation{{UserId: 7}, {UserId: 1, GuildId: 2, Roles: []common.Snowflake{9}}} {
+			for range 3 {
+				if _, err := limits.Acquire(bypassed); err != nil {
+					t.Fatalf("bypassed invocation %+v refused: %v", bypassed, err)
+				}
+			}
+		}
+	})
+
+	t.Run("nil limits", func(t *testing.T) {
+
+		var limits *Limits
+		if _, err := limits.Acquire(inv); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestFormatRetry(t *testing.T) {
+
+	tests := []struct {
+		wait time.Duration
+		want string
+	}{
+		{time.Millisecond, "1s"},
+		{12 * time.Second, "12s"},
+		{11*time.Second + time.Millisecond, "12s"},
+		{time.Minute, "1m"},
+		{2*time.Minute + 5*time.Second, "2m5s"},
+		{time.Hour, "1h"},
+		{time.Hour + 30*time.Minute, "1h30m"},
+	}
+
+	for _, test := range tests {
+		if got := FormatRetry(test.wait); got != test.want {
+			t.Errorf("FormatRetry(%s) = %q, want %q", test.wait, got, test.want)
+		}
+	}
+}
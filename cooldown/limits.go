@@ -0,0 +1,199 @@
+package cooldown
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"brandenly.com/go/packages/discord-bot/common"
+)
+
+// Reply to an invocation refused by a cooldown; "{retry}" is replaced by the time left, such as "12s"
+const DefaultCooldownMessage = "Slow down! Try again in {retry}."
+
+// Reply to an invocation refused by a concurrency limit
+const DefaultBusyMessage = "This command is already running; try again once it has finished."
+
+// Returned when limits refuse an invocation. Message is the reply to send to the user.
+type Error struct {
+	RetryAfter time.Duration // How long until the invocation would be allowed; zero when refused by a concurrency limit
+	Message    string        // Reply to send to the user
+}
+
+func (e *Error) Error() string {
+
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("on cooldown for %s", FormatRetry(e.RetryAfter))
+	}
+
+	return "too many concurrent uses"
+}
+
+// Cooldowns and concurrency limits applied to a command, checked before its handler runs.
+type Limits struct {
+	Cooldowns       []*Cooldown  // All must allow an invocation
+	Concurrency     *Concurrency // Optional; held while the handler runs
+	Bypass          *Bypass      // Optional; users and roles not subject to the limits
+	CooldownMessage string       // Reply when on cooldown, with "{retry}" replaced by the time left; defaults to DefaultCooldownMessage
+	BusyMessage     string       // Reply when the concurrency limit is reached; defaults to DefaultBusyMessage
+}
+
+// Returns limits made of cooldowns.
+func With(cooldowns ...*Cooldown) *Limits {
+	return &Limits{Cooldowns: cooldowns}
+}
+
+// Sets the concurrency limit, and returns the limits.
+func (l *Limits) WithConcurrency(concurrency *Concurrency) *Limits {
+	l.Concurrency = concurrency
+	return l
+}
+
+// Sets who bypasses the limits, and returns the limits.
+func (l *Limits) WithBypass(bypass *Bypass) *Limits {
+	l.Bypass = bypass
+	return l
+}
+
+// Starts an invocation, returning a func to call once the command has finished. Returns an *Error
+// if a cooldown or the concurrency limit refuses it, in which case no use is counted.
+func (l *Limits) Acquire(inv Invocation) (release func(), err error) {
+
+	if l == nil || l.Bypass.Allows(inv) {
+		return func() {}, nil
+	}
+
+	release = func() {}
+	if l.Concurrency != nil {
+
+		var ok bool
+		if release, ok = l.Concurrency.Acquire(inv); !ok {
+			return nil, &Error{Message: or(l.BusyMessage, DefaultBusyMessage)}
+		}
+	}
+
+	now := time.Now()
+	for i, cooldown := range l.Cooldowns {
+
+		retryAfter, ok := cooldown.Take(inv, now)
+		if ok {
+			continue
+		}
+
+		for _, taken := range l.Cooldowns[:i] {
+			taken.refund(inv)
+		}
+		release()
+
+		message := strings.ReplaceAll(or(l.CooldownMessage, DefaultCooldownMessage), "{retry}", FormatRetry(retryAfter))
+		return nil, &Error{RetryAfter: retryAfter, Message: message}
+	}
+
+	return release, nil
+}
+
+// Returns a wait rounded up to whole seconds, such as "12s", "2m5s" or "1h".
+func FormatRetry(wait time.Duration) string {
+
+	formatted := (time.Duration(math.Ceil(wait.Seconds())) * time.Second).String()
+	if strings.HasSuffix(formatted, "m0s") {
+		formatted = strings.TrimSuffix(formatted, "0s")
+	}
+	if strings.HasSuffix(formatted, "h0m") {
+		formatted = strings.TrimSuffix(formatted, "0m")
+	}
+
+	return formatted
+}
+
+func or(value string, fallback string) string {
+
+	if value == "" {
+		return fallback
+	}
+
+	return value
+}
+
+//// Bypass
+
+// Users, and roles in each guild, that aren't subject to limits. Roles are meant to be configured by
+// each guild's owner, such as through a settings command.
+type Bypass struct {
+	mu    sync.RWMutex
+	users map[common.Snowflake]bool
+	roles map[common.Snowflake]map[common.Snowflake]bool
+}
+
+// Returns a bypass for users, such as the application's owners.
+func NewBypass(users ...common.Snowflake) *Bypass {
+
+	b := &Bypass{users: map[common.Snowflake]bool{}, roles: map[common.Snowflake]map[common.Snowflake]bool{}}
+	for _, user := range users {
+		b.users[user] = true
+	}
+
+	return b
+}
+
+// Sets the roles that bypass limits in a guild, replacing any set before. Setting none removes them.
+func (b *Bypass) SetRoles(guildId common.Snowflake, roles ...common.Snowflake) {
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(roles) == 0 {
+		delete(b.roles, guildId)
+		return
+	}
+
+	set := make(map[common.Snowflake]bool, len(roles))
+	for _, role := range roles {
+		set[role] = true
+	}
+
+	if b.roles == nil {
+		b.roles = map[common.Snowflake]map[common.Snowflake]bool{}
+	}
+	b.roles[guildId] = set
+}
+
+// Returns the roles that bypass limits in a guild.
+func (b *Bypass) Roles(guildId common.Snowflake) []common.Snowflake {
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	roles := make([]common.Snowflake, 0, len(b.roles[guildId]))
+	for role := range b.roles[guildId] {
+		roles = append(roles, role)
+	}
+
+	return roles
+}
+
+// Reports whether inv's user, or one of its roles in the guild, bypasses limits. Safe on a nil
+// bypass, which allows no one.
+func (b *Bypass) Allows(inv Invocation) bool {
+
+	if b == nil {
+		return false
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.users[inv.UserId] {
+		return true
+	}
+
+	for _, role := range inv.Roles {
+		if b.roles[inv.GuildId][role] {
+			return true
+		}
+	}
+
+	return false
+}
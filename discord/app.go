@@ -7,36 +7,19 @@ import (
 	"io"
 	"log"
 	"net/http"
-	"strconv"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"brandenly.com/go/packages/discord-bot/common"
 	"brandenly.com/go/packages/discord-bot/gateway"
+	"brandenly.com/go/packages/discord-bot/state"
 )
 
 const ApiBaseUrl = "https://discord.com/api"
 
-var BotIntents map[string]int = map[string]int{
-	"GUILDS":                        1 << 0,
-	"GUILD_MEMBERS":                 1 << 1,
-	"GUILD_BANS":                    1 << 2,
-	"GUILD_EMOJIS_AND_STICKERS":     1 << 3,
-	"GUILD_INTEGRATIONS":            1 << 4,
-	"GUILD_WEBHOOKS":                1 << 5,
-	"GUILD_INVITES":                 1 << 6,
-	"GUILD_VOICE_STATES":            1 << 7,
-	"GUILD_PRESENCES":               1 << 8,
-	"GUILD_MESSAGES":                1 << 9,
-	"GUILD_MESSAGE_REACTIONS":       1 << 10,
-	"GUILD_MESSAGE_TYPING":          1 << 11,
-	"DIRECT_MESSAGES":               1 << 12,
-	"DIRECT_MESSAGE_REACTIONS":      1 << 13,
-	"DIRECT_MESSAGE_TYPING":         1 << 14,
-	"MESSAGE_CONTENT":               1 << 15,
-	"GUILD_SCHEDULED_EVENTS":        1 << 16,
-	"AUTO_MODERATION_CONFIGURATION": 1 << 20,
-	"AUTO_MODERATION_EXECUTION":     1 << 21,
-}
+// Gateway intents by name.
+var BotIntents map[string]gateway.Intents = gateway.IntentNames
 
 // External reference: https://discord.com/developers/docs/resources/application#install-params-object
 type InstallParams struct {
@@ -51,12 +34,25 @@ type IntegrationTypesConfig struct {
 
 // External reference: https://discord.com/developers/docs/resources/application#application-object
 type App struct {
-	Id                uint64      `json:"-" discord-bot:"internal"` // ID of the app
-	PublicKey         string      `json:"-" discord-bot:"internal"` // App Public Key
-	BotToken          string      `json:"-" discord-bot:"internal"` // App Bot Token
-	DiscordApiBaseUrl string      `json:"-" discord-bot:"internal"` // Discord base url; the base url used for requests to discord's REST api.
-	Color             int         `json:"-" discord-bot:"internal"` // Color
-	Logger            *log.Logger `json:"-" discord-bot:"internal"` // The logger to use for application state changes.
+	Id                common.Snowflake  `json:"-" discord-bot:"internal"` // ID of the app
+	PublicKey         string            `json:"-" discord-bot:"internal"` // App Public Key
+	BotToken          string            `json:"-" discord-bot:"internal"` // App Bot Token
+	DiscordApiBaseUrl string            `json:"-" discord-bot:"internal"` // Discord base url; the base url used for requests to discord's REST api.
+	Color             int               `json:"-" discord-bot:"internal"` // Color
+	Logger            *log.Logger       `json:"-" discord-bot:"internal"` // The logger to use for application state changes.
+	Recorder          *gateway.Recorder `json:"-" discord-bot:"internal"` // Optional; records raw frames received by every gateway connection.
+	GatewayQueueSize  int               `json:"-" discord-bot:"internal"` // Buffer size of each gateway connection's queues, defaults to gateway.DefaultQueueSize
+	DispatchWorkers   int               `json:"-" discord-bot:"internal"` // Number of concurrent dispatch lanes, defaults to DefaultDispatchWorkers
+	DispatchQueueSize int               `json:"-" discord-bot:"internal"` // Buffer size of each dispatch lane, defaults to DefaultDispatchQueueSize
+	DispatchTimeout   time.Duration     `json:"-" discord-bot:"internal"` // How long to wait on a full dispatch lane before dropping an event; zero waits indefinitely
+	SlowHandler       time.Duration     `json:"-" discord-bot:"internal"` // Handler duration reported as slow, defaults to DefaultSlowHandlerThreshold
+
+	GuildsLoadedTimeout time.Duration `json:"-" discord-bot:"internal"` // How long a shard waits for its guilds to load after READY, defaults to DefaultGuildsLoadedTimeout
+
+	State *state.Cache `json:"-" discord-bot:"internal"` // Optional; kept up to date with every dispatch event before handlers run, and required for the enriched message events
+
+	Middlewares  []Middleware                          `json:"-" discord-bot:"internal"` // Wrap every event handler, outermost first
+	ErrorHandler func(event *gateway.Event, err error) `json:"-" discord-bot:"internal"` // Receives handler errors; errors are logged when unset
 
 	Name                            string                 `json:"name"`                               // Name of the app
 	Icon                            string                 `json:"icon"`                               // Icon hash of the app
@@ -70,9 +66,9 @@ type App struct {
 	Owner                           common.User            `json:"owner"`                              // Partial user object for the owner of the app
 	VerifyKey                       string                 `json:"verify_key"`                         // Hex encoded key for verification in interactions and the GameSDK's GetTicket
 	Team                            common.Team            `json:"team"`                               // If the app belongs to a team, this will be a list of the members of that team
-	GuildId                         string                 `json:"guild_id"`                           // Guild associated with the app. For example, a developer support server.
+	GuildId                         common.Snowflake       `json:"guild_id"`                           // Guild associated with the app. For example, a developer support server.
 	Guild                           common.Guild           `json:"guild"`                              // Partial object of the associated guild
-	PrimarySkuId                    string                 `json:"primary_sku_id"`                     // If this app is a game sold on Discord, this field will be the id of the "Game SKU" that is created, if exists
+	PrimarySkuId                    common.Snowflake       `json:"primary_sku_id"`                     // If this app is a game sold on Discord, this field will be the id of the "Game SKU" that is created, if exists
 	Slug                            string                 `json:"slug"`                               // If this app is a game sold on Discord, this field will be the URL slug that links to the store page
 	CoverImage                      string                 `json:"cover_image"`                        // App's default rich presence invite cover image hash
 	Flags                           int                    `json:"flags"`                              // App's public flags
@@ -90,9 +86,17 @@ type App struct {
 	CustomInstallUrl                string                 `json:"custom_install_url"`                 // Default custom authorization URL for the app, if enabled
 
 	GatewayEventHandlers []GatewayEventHandler `json:"-" discord-bot:"internal"`
+	handlersMu           sync.Mutex            `json:"-" discord-bot:"internal"`
+	nextHandlerId        uint64                `json:"-" discord-bot:"internal"`
 	Cancel               context.CancelFunc    `json:"-" discord-bot:"internal"`
 	ctx                  context.Context       `json:"-" discord-bot:"internal"`
 	gatewayConnections   []*gateway.Connection `json:"-" discord-bot:"internal"`
+	dispatcher           *dispatcher           `json:"-" discord-bot:"internal"`
+	receiving            atomic.Bool           `json:"-" discord-bot:"internal"`
+	stats                dispatchStats         `json:"-" discord-bot:"internal"`
+	shards               shardTracker          `json:"-" discord-bot:"internal"`
+	guilds               guildTracker          `json:"-" discord-bot:"internal"`
+	intents              gateway.Intents       `json:"-" discord-bot:"internal"`
 
 	HttpClient          *http.Client
 	ExternalConnections sync.WaitGroup
@@ -140,6 +144,24 @@ func (a *App) Start(ctx context.Context, config gateway.GatewayBotUrlResponse, i
 		return fmt.Errorf("unable to unmarshal retrieved application details: %w", err)
 	}
 
+	// Start dispatching
+	if a.GatewayQueueSize <= 0 {
+		a.GatewayQueueSize = gateway.DefaultQueueSize
+	}
+
+	if a.SlowHandler <= 0 {
+		a.SlowHandler = DefaultSlowHandlerThreshold
+	}
+
+	a.dispatcher = newDispatcher(a.DispatchWorkers, a.DispatchQueueSize, a.dispatch)
+	a.receiving.Store(true)
+
+	// Warn about handlers the requested intents will never deliver events to
+	if data, ok := identify.D.(gateway.Identify); ok {
+		a.intents = data.Intents
+		a.checkIntents()
+	}
+
 	// Start gateway connections
 	a.ExternalConnections.Add(config.Shards)
 	for i := range config.Shards {
@@ -149,10 +171,12 @@ func (a *App) Start(ctx context.Context, config gateway.GatewayBotUrlResponse, i
 		a.Logger.Printf("Attempting to establish gateway connection %d/%d\n", shardNum, config.Shards)
 
 		var conn gateway.Connection = gateway.Connection{
-			Outgoing:   make(chan gateway.Event),
-			Incoming:   make(chan gateway.Event),
+			Outgoing:   make(chan gateway.Event, a.GatewayQueueSize),
+			Incoming:   make(chan gateway.Event, a.GatewayQueueSize),
 			ShardIndex: i,
 			BotToken:   &a.BotToken,
+			Recorder:   a.Recorder,
+			QueueSize:  a.GatewayQueueSize,
 		}
 
 		go func() {
@@ -167,6 +191,7 @@ func (a *App) Start(ctx context.Context, config gateway.GatewayBotUrlResponse, i
 		a.gatewayConnections = append(a.gatewayConnections, &conn)
 	}
 
+	a.ExternalConnections.Add(1)
 	go a.Receive()
 
 	return nil
@@ -202,64 +227,132 @@ func (a *App) Make(req *http.Request) (*[]byte, error) {
 }
 
 // Send a gateway event to one of the gateway connections
-func (a *App) Send(event gateway.Event, guildId string) error {
+func (a *App) Send(event gateway.Event, guildId common.Snowflake) error {
 
 	// Determine which shard should handle the event
-	guildIdInt, err := strconv.Atoi(guildId)
-	if err != nil {
-		return fmt.Errorf("unable to retrieve guild id as int: %w", err)
-	}
-
-	shardNum := (guildIdInt >> 22) % len(a.gatewayConnections)
+	shardNum := int(uint64(guildId>>22) % uint64(len(a.gatewayConnections)))
 
 	a.Logger.Printf("Sending event to connection %d", shardNum)
 
 	return nil
 }
 
-// Handle connections' incoming gateway events
+// Handle connections' incoming gateway events. One reader per connection feeds the dispatcher until
+// the application context is cancelled, after which queued events are drained before returning.
 func (a *App) Receive() {
 	defer a.ExternalConnections.Done()
 
+	var readers sync.WaitGroup
+
+	readers.Add(len(a.gatewayConnections))
+	for _, conn := range a.gatewayConnections {
+		go func() {
+			defer readers.Done()
+			a.read(conn)
+		}()
+	}
+
+	readers.Wait()
+	a.receiving.Store(false)
+	a.guilds.stop()
+	a.dispatcher.close()
+
+	a.Logger.Printf("Stopped receiving gateway events")
+}
+
+// Forwards a single connection's incoming events to the dispatcher.
+func (a *App) read(conn *gateway.Connection) {
 	for {
-		for _, conn := range a.gatewayConnections {
-			select {
-			case event, ok := <-conn.Incoming:
-
-				if !ok {
-					// Channel is closed wait to see if its restored
-					continue
-				}
-
-				eventData, err := json.Marshal(event.D)
-				if err != nil {
-					panic(fmt.Errorf("unable to marshal incoming payload data: %w", err))
-				}
-
-				if event.Op == 0 {
-					a.Logger.Printf("Incoming event %s (\"%s\"): %s\n", OpCodes[event.Op], *event.T, eventData)
-				} else {
-					a.Logger.Printf("Incoming event %s: %s\n", OpCodes[event.Op], eventData)
-				}
-
-				if event.Op == 0 { // Pass dispatch events to corresponding handlers
-					for _, handler := range a.GatewayEventHandlers {
-
-						if *event.T == handler.Type {
-							err := handler.Fn(&event, a) // Execute handler
-							if err != nil {
-								a.Logger.Printf("error occurred while executing event handler: %s", err.Error())
-							}
-						}
-
-					}
-				}
-			default:
-				// Do nothing
+		select {
+
+		case <-a.ctx.Done():
+			return // Stop reading
+
+		case event, ok := <-conn.Incoming:
+
+			if !ok {
+				return // Connection closed its channel
+			}
+
+			a.stats.received.Add(1)
+
+			if !a.enqueue(a.ctx, a.dispatcher, event, a.DispatchTimeout) {
+				a.stats.dropped.Add(1)
+				a.Logger.Printf("Dropped incoming event from connection %d", event.Shard+1)
 			}
 		}
 	}
+}
+
+// Returns a snapshot of the application's dispatch counters.
+func (a *App) Metrics() DispatchMetrics {
+
+	metrics := DispatchMetrics{
+		Received:     a.stats.received.Load(),
+		Dispatched:   a.stats.dispatched.Load(),
+		Dropped:      a.stats.dropped.Load(),
+		SlowHandlers: a.stats.slowHandlers.Load(),
+	}
+
+	if a.dispatcher != nil {
+		metrics.Queued = a.dispatcher.queued()
+	}
+
+	return metrics
+}
+
+// Logs an incoming gateway event and passes dispatch events to their corresponding handlers
+func (a *App) handle(event gateway.Event) {
+
+	eventData, err := json.Marshal(event.D)
+	if err != nil {
+		panic(fmt.Errorf("unable to marshal incoming payload data: %w", err))
+	}
+
+	if event.Op == 0 {
+		a.Logger.Printf("Incoming event %s (\"%s\"): %s\n", OpCodes[event.Op], *event.T, eventData)
+	} else {
+		a.Logger.Printf("Incoming event %s: %s\n", OpCodes[event.Op], eventData)
+	}
+
+	var enriched []gateway.Event
+	if event.Op == 0 && a.State != nil { // Update cached state before handlers observe the event
+		a.guard(&event, func() { enriched = a.State.Handle(&event) })
+	}
+
+	if event.Op == 0 { // Pass dispatch events to corresponding handlers
+		for _, handler := range a.handlersFor(*event.T) {
+
+			started := time.Now()
+
+			err := a.chain(handler)(a.context(), &event, a) // Execute handler
+			if err != nil {
+				a.handlerError(&event, err)
+			}
+
+			if elapsed := time.Since(started); a.SlowHandler > 0 && elapsed > a.SlowHandler {
+				a.stats.slowHandlers.Add(1)
+				a.Logger.Printf("Slow %s handler took %s", handler.Type, elapsed)
+			}
+
+		}
+	}
+
+	a.stats.dispatched.Add(1)
+
+	var lifecycle []gateway.Event
+	a.guard(&event, func() { lifecycle = a.observeLifecycle(&event) })
+
+	// Dispatch events derived from this event, enriched events first
+	for _, derived := range append(enriched, lifecycle...) {
+		a.handle(derived)
+	}
+}
 
+// Handles an event taken from a dispatch lane, reporting a panic through the application's error
+// handler so that it doesn't stop the lane or crash the application.
+func (a *App) dispatch(event gateway.Event) {
+	a.guard(&event, func() { a.handle(event) })
 }
 
 //// Additional methods
@@ -271,7 +364,7 @@ func (a *App) UnmarshalJSON(data []byte) error {
 	}
 
 	if idValue, ok := raw["id"].(string); ok {
-		parsedID, err := strconv.ParseUint(idValue, 10, 64)
+		parsedID, err := common.ParseSnowflake(idValue)
 		if err != nil {
 			return fmt.Errorf("failed to parse id: %w", err)
 		}
@@ -0,0 +1,75 @@
+package discord
+
+import (
+	"context"
+	"io"
+	"log"
+	"testing"
+
+	"brandenly.com/go/packages/discord-bot/gateway"
+	"brandenly.com/go/packages/discord-bot/state"
+)
+
+// A cache store that panics on every write.
+type panickingStore struct {
+	state.CacheStore
+}
+
+func (panickingStore) Put(kind state.Kind, key state.Key, value any) {
+	panic("store unavailable")
+}
+
+func TestDispatchRecoversPanics(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		state   *state.Cache
+		event   gateway.Event
+		handled bool // Whether handlers still run for the event
+	}{
+		{
+			name:    "state update",
+			state:   state.NewWithStore(panickingStore{state.NewMemoryStore(nil)}, state.Policy{}),
+			event:   gateway.NewSyntheticEvent("MESSAGE_CREATE", 0, &gateway.MessageCreate{Id: 1, ChannelId: 2}),
+			handled: true,
+		},
+		{
+			name:  "unencodable payload",
+			event: gateway.NewSyntheticEvent("MESSAGE_CREATE", 0, make(chan int)),
+		},
+		{
+			name:  "non-dispatch event",
+			event: gateway.Event{Op: 11, D: make(chan int)},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			var errs []error
+			a := &App{
+				Logger:       log.New(io.Discard, "", 0),
+				State:        test.state,
+				ErrorHandler: func(event *gateway.Event, err error) { errs = append(errs, err) },
+			}
+
+			var handled bool
+			On(a, func(ctx context.Context, a *App, m *gateway.MessageCreate) error {
+				handled = true
+				return nil
+			})
+
+			a.dispatch(test.event)
+
+			if len(errs) != 1 {
+				t.Fatalf("got errors %v, want a single panic", errs)
+			}
+			if _, ok := errs[0].(*PanicError); !ok {
+				t.Errorf("got %T, want *PanicError", errs[0])
+			}
+			if handled != test.handled {
+				t.Errorf("handled = %v, want %v", handled, test.handled)
+			}
+		})
+	}
+}
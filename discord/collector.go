@@ -0,0 +1,224 @@
+package discord
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"brandenly.com/go/packages/discord-bot/common"
+	"brandenly.com/go/packages/discord-bot/gateway"
+)
+
+// Default buffer size of a collector without a maximum event count
+const DefaultCollectorBuffer = 100
+
+// Reports whether a dispatch event should be collected. A nil predicate matches every event.
+type Predicate func(event *gateway.Event) bool
+
+// Blocks until a dispatch event of eventType matching predicate arrives, returning it, or until ctx
+// ends. The temporary handler is always removed before returning.
+func (a *App) WaitFor(ctx context.Context, eventType string, predicate Predicate) (*gateway.Event, error) {
+
+	collector := a.NewCollector(ctx, eventType, predicate, CollectorOptions{Max: 1})
+	defer collector.Stop()
+
+	select {
+	case event, ok := <-collector.Events:
+		if !ok {
+			return nil, ctx.Err()
+		}
+		return event, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Waits for the next dispatch event whose data decodes into T and matches predicate. See On for how
+// the event name is inferred from T.
+func Await[T any](ctx context.Context, a *App, predicate func(*T) bool) (*T, error) {
+
+	event, err := a.WaitFor(ctx, inferEventType[T](), func(event *gateway.Event) bool {
+		data, ok := event.D.(*T)
+		return ok && (predicate == nil || predicate(data))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return event.D.(*T), nil
+}
+
+// Gathers up to options.Max matching dispatch events that arrive within options.Window. Collection
+// ending because the limit was reached or the window elapsed is not an error; if ctx ends first the
+// events gathered so far are returned along with ctx's error.
+func (a *App) Collect(ctx context.Context, eventType string, predicate Predicate, options CollectorOptions) ([]*gateway.Event, error) {
+
+	collector := a.NewCollector(ctx, eventType, predicate, options)
+	defer collector.Stop()
+
+	var events []*gateway.Event
+	for event := range collector.Events {
+		events = append(events, event)
+	}
+
+	if ctx.Err() != nil {
+		return events, ctx.Err()
+	}
+
+	return events, nil
+}
+
+//// Collectors
+
+// Limits for a collector. A zero value collects until stopped or its context ends.
+type CollectorOptions struct {
+	Max    int           // Stop after this many events; zero for no limit
+	Window time.Duration // Stop once this much time has passed; zero for no time limit
+}
+
+// Streams matching dispatch events to Events until its limits are reached, its context ends or it
+// is stopped, at which point Events is closed and the underlying handler removed.
+type Collector struct {
+	Events <-chan *gateway.Event // Matching events, in the order they were dispatched
+
+	events      chan *gateway.Event
+	predicate   Predicate
+	max         int
+	count       int
+	closed      bool
+	mu          sync.Mutex
+	unsubscribe func()
+	stop        context.CancelFunc
+}
+
+// Starts collecting dispatch events of eventType that match predicate. Events that arrive while the
+// collector's buffer is full are discarded rather than delaying dispatch.
+func (a *App) NewCollector(ctx context.Context, eventType string, predicate Predicate, options CollectorOptions) *Collector {
+
+	buffer := options.Max
+	if buffer <= 0 {
+		buffer = DefaultCollectorBuffer
+	}
+
+	c := &Collector{
+		events:    make(chan *gateway.Event, buffer),
+		predicate: predicate,
+		max:       options.Max,
+	}
+	c.Events = c.events
+
+	if options.Window > 0 {
+		ctx, c.stop = context.WithTimeout(ctx, options.Window)
+	} else {
+		ctx, c.stop = context.WithCancel(ctx)
+	}
+
+	c.unsubscribe = a.AddHandler(GatewayEventHandler{
+		Type: eventType,
+		Fn: func(event *gateway.Event, a *App) error {
+			c.offer(event)
+			return nil
+		},
+	})
+
+	go func() {
+		<-ctx.Done()
+		c.close()
+	}()
+
+	return c
+}
+
+// Stops collecting and closes Events. Safe to call more than once.
+func (c *Collector) Stop() {
+	c.stop()
+	c.close()
+}
+
+// Delivers an event to the collector if it matches and the collector is still open.
+func (c *Collector) offer(event *gateway.Event) {
+
+	if c.predicate != nil && !c.predicate(event) {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return
+	}
+
+	collected := *event // Dispatch reuses its event, keep a copy
+
+	select {
+	case c.events <- &collected:
+		c.count++
+	default:
+		return // Buffer is full
+	}
+
+	if c.max > 0 && c.count >= c.max {
+		c.closeLocked()
+	}
+}
+
+func (c *Collector) close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.closeLocked()
+}
+
+func (c *Collector) closeLocked() {
+
+	if c.closed {
+		return
+	}
+
+	c.closed = true
+	close(c.events)
+	c.unsubscribe()
+}
+
+//// Predicates
+
+// Matches events that satisfy every predicate.
+func All(predicates ...Predicate) Predicate {
+	return func(event *gateway.Event) bool {
+		for _, predicate := range predicates {
+			if predicate != nil && !predicate(event) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Matches events triggered by the given user.
+func FromUser(userId common.Snowflake) Predicate {
+	return func(event *gateway.Event) bool {
+		return event.UserId() == userId
+	}
+}
+
+// Matches events in the given channel.
+func InChannel(channelId common.Snowflake) Predicate {
+	return func(event *gateway.Event) bool {
+		return event.ChannelId() == channelId
+	}
+}
+
+// Matches events in the given guild.
+func InGuild(guildId common.Snowflake) Predicate {
+	return func(event *gateway.Event) bool {
+		return event.GuildId() == guildId
+	}
+}
+
+// Matches events relating to the given message, such as reactions added to it.
+func OnMessage(messageId common.Snowflake) Predicate {
+	return func(event *gateway.Event) bool {
+		return event.MessageId() == messageId
+	}
+}
@@ -0,0 +1,151 @@
+package discord
+
+import (
+	"context"
+	"hash/fnv"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"brandenly.com/go/packages/discord-bot/gateway"
+)
+
+const (
+	DefaultDispatchWorkers   = 16  // Default number of dispatch lanes
+	DefaultDispatchQueueSize = 128 // Default buffer size of each dispatch lane
+
+	DefaultSlowHandlerThreshold = 3 * time.Second // Default duration after which a handler is reported as slow
+)
+
+// A point in time snapshot of the application's dispatch counters.
+type DispatchMetrics struct {
+	Received     uint64 // Events read from gateway connections
+	Dispatched   uint64 // Events that finished dispatching to their handlers
+	Dropped      uint64 // Events discarded because a lane stayed full past the dispatch timeout, or the app was stopping
+	SlowHandlers uint64 // Handler executions that exceeded the slow handler threshold
+	Queued       int    // Events currently waiting in dispatch lanes
+}
+
+// Counters backing DispatchMetrics; safe for concurrent use.
+type dispatchStats struct {
+	received     atomic.Uint64
+	dispatched   atomic.Uint64
+	dropped      atomic.Uint64
+	slowHandlers atomic.Uint64
+}
+
+// Runs event handlers on a fixed set of lanes. Events are assigned a lane by guild so dispatch is
+// concurrent across guilds while events for any one guild are handled one at a time, in order.
+type dispatcher struct {
+	lanes  []chan gateway.Event
+	wg     sync.WaitGroup
+	mu     sync.RWMutex // Held for reading while queueing, so lanes aren't closed during a send
+	closed bool
+}
+
+// Creates a dispatcher and starts one goroutine per lane, each calling fn for the events assigned to it.
+func newDispatcher(workers int, queueSize int, fn func(gateway.Event)) *dispatcher {
+
+	if workers <= 0 {
+		workers = DefaultDispatchWorkers
+	}
+
+	if queueSize <= 0 {
+		queueSize = DefaultDispatchQueueSize
+	}
+
+	d := &dispatcher{lanes: make([]chan gateway.Event, workers)}
+
+	d.wg.Add(workers)
+	for i := range d.lanes {
+		d.lanes[i] = make(chan gateway.Event, queueSize)
+
+		go func(lane chan gateway.Event) {
+			defer d.wg.Done()
+			for event := range lane {
+				fn(event)
+			}
+		}(d.lanes[i])
+	}
+
+	return d
+}
+
+// Queues an event on its lane, blocking while the lane is full. Returns false if ctx ends first, or if
+// timeout is positive and the lane stays full for longer than timeout.
+func (d *dispatcher) enqueue(ctx context.Context, event gateway.Event, timeout time.Duration) bool {
+	return d.enqueueOn(ctx, d.lane(&event), event, timeout)
+}
+
+// Queues an event on the given lane. See dispatcher.enqueue. Returns false once the dispatcher is
+// closed.
+func (d *dispatcher) enqueueOn(ctx context.Context, lane int, event gateway.Event, timeout time.Duration) bool {
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if d.closed {
+		return false
+	}
+
+	var expired <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	select {
+	case d.lanes[lane] <- event:
+		return true
+	case <-ctx.Done():
+		return false
+	case <-expired:
+		return false
+	}
+}
+
+// Returns the number of events waiting across all lanes.
+func (d *dispatcher) queued() int {
+	total := 0
+	for _, lane := range d.lanes {
+		total += len(lane)
+	}
+	return total
+}
+
+// Selects the lane for an event. Guild events are keyed by guild, direct messages by channel and
+// everything else by the shard that received it.
+func (d *dispatcher) lane(event *gateway.Event) int {
+
+	key := event.GuildId().String()
+	if key == "0" {
+		key = event.ChannelId().String()
+	}
+	if key == "0" {
+		key = "shard:" + strconv.Itoa(event.Shard)
+	}
+
+	hash := fnv.New32a()
+	hash.Write([]byte(key))
+
+	return int(hash.Sum32() % uint32(len(d.lanes)))
+}
+
+// Stops accepting events and waits for queued events to finish dispatching.
+func (d *dispatcher) close() {
+
+	d.mu.Lock()
+	if d.closed {
+		d.mu.Unlock()
+		return
+	}
+	d.closed = true
+	for _, lane := range d.lanes {
+		close(lane)
+	}
+	d.mu.Unlock()
+
+	d.wg.Wait()
+}
@@ -1,9 +1,201 @@
 package discord
 
-import "brandenly.com/go/packages/discord-bot/gateway"
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"brandenly.com/go/packages/discord-bot/gateway"
+)
 
 // Called when an event matching the handlers type is received.
 type GatewayEventHandler struct {
 	Type string
 	Fn   func(*gateway.Event, *App) error
+	Once bool // Remove the handler after its first invocation
+
+	Middlewares []Middleware // Wrap this handler only, inside the application's middlewares
+
+	run   HandlerFunc  // Context aware alternative to Fn, used by typed handlers
+	id    uint64       // Assigned by AddHandler, zero for handlers set directly on App.GatewayEventHandlers
+	fired *atomic.Bool // Set once a one-shot handler has run, shared by copies of the handler
+}
+
+// Registers an event handler, returning a function that removes it again. Safe to call while the
+// application is running.
+func (a *App) AddHandler(handler GatewayEventHandler) (unsubscribe func()) {
+
+	a.handlersMu.Lock()
+	defer a.handlersMu.Unlock()
+
+	a.nextHandlerId++
+	handler.id = a.nextHandlerId
+
+	a.GatewayEventHandlers = append(a.GatewayEventHandlers, handler)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { a.removeHandler(handler.id) })
+	}
+}
+
+// Removes the handler with the given registration id.
+func (a *App) removeHandler(id uint64) {
+
+	a.handlersMu.Lock()
+	defer a.handlersMu.Unlock()
+
+	a.GatewayEventHandlers = slices.DeleteFunc(a.GatewayEventHandlers, func(h GatewayEventHandler) bool {
+		return h.id == id
+	})
+}
+
+// Returns the handlers registered for an event type, leaving out one-shot handlers that have already
+// run.
+func (a *App) handlersFor(eventType string) []GatewayEventHandler {
+
+	a.handlersMu.Lock()
+	defer a.handlersMu.Unlock()
+
+	var matched []GatewayEventHandler
+
+	for i, handler := range a.GatewayEventHandlers {
+		if handler.Type != eventType {
+			continue
+		}
+
+		if handler.Once {
+			if handler.fired == nil { // Set directly on App.GatewayEventHandlers
+				handler.fired = new(atomic.Bool)
+				a.GatewayEventHandlers[i].fired = handler.fired
+			}
+			if handler.fired.Load() {
+				continue
+			}
+		}
+
+		matched = append(matched, handler)
+	}
+
+	return matched
+}
+
+// Wraps a one-shot handler so that it runs at most once and is removed when it does. Middlewares that
+// skip the handler, such as filters, leave it registered for later events.
+func (a *App) runOnce(handler GatewayEventHandler, fn HandlerFunc) HandlerFunc {
+	return func(ctx context.Context, event *gateway.Event, app *App) error {
+
+		if !handler.fired.CompareAndSwap(false, true) {
+			return nil
+		}
+
+		a.handlersMu.Lock()
+		a.GatewayEventHandlers = slices.DeleteFunc(a.GatewayEventHandlers, func(h GatewayEventHandler) bool {
+			return h.fired == handler.fired
+		})
+		a.handlersMu.Unlock()
+
+		return fn(ctx, event, app)
+	}
+}
+
+// Returns the application context, or a background context when the application has not started.
+func (a *App) context() context.Context {
+	if a.ctx == nil {
+		return context.Background()
+	}
+	return a.ctx
+}
+
+//// Typed handlers
+
+var (
+	eventTypesOnce sync.Once
+	eventTypes     map[reflect.Type][]string // Event names keyed by the type of their data
+)
+
+// Returns the dispatch event names whose data decodes into T, derived from gateway.EventTypeStructs
+// and the synthetic event maps.
+func EventTypesOf[T any]() []string {
+
+	eventTypesOnce.Do(func() {
+		eventTypes = map[reflect.Type][]string{}
+
+		for _, structs := range []map[string]func() any{gateway.EventTypeStructs, gateway.LifecycleEventTypeStructs, gateway.MessageHistoryEventTypeStructs} {
+			for name, constructor := range structs {
+				dataType := reflect.TypeOf(constructor())
+				if dataType.Kind() == reflect.Pointer {
+					dataType = dataType.Elem()
+				}
+				eventTypes[dataType] = append(eventTypes[dataType], name)
+			}
+		}
+
+		for _, names := range eventTypes {
+			slices.Sort(names)
+		}
+	})
+
+	return slices.Clone(eventTypes[reflect.TypeFor[T]()])
+}
+
+// Registers a handler for the dispatch event whose data decodes into T, for example
+//
+//	discord.On(app, func(ctx context.Context, a *discord.App, m *gateway.MessageCreate) error { ... })
+//
+// Panics if T is not the data type of exactly one event; use OnEvent for data types shared by
+// several events, such as common.Channel.
+func On[T any](a *App, fn func(context.Context, *App, *T) error) (unsubscribe func()) {
+	return a.AddHandler(typedHandler(inferEventType[T](), false, fn))
+}
+
+// Registers a handler that runs for the first matching dispatch event only. See On.
+func Once[T any](a *App, fn func(context.Context, *App, *T) error) (unsubscribe func()) {
+	return a.AddHandler(typedHandler(inferEventType[T](), true, fn))
+}
+
+// Registers a handler for the named dispatch event. Panics if the event's data does not decode into T.
+func OnEvent[T any](a *App, eventType string, fn func(context.Context, *App, *T) error) (unsubscribe func()) {
+
+	if !slices.Contains(EventTypesOf[T](), eventType) {
+		panic(fmt.Errorf("event %s does not carry %s data", eventType, reflect.TypeFor[T]()))
+	}
+
+	return a.AddHandler(typedHandler(eventType, false, fn))
+}
+
+// Returns the single event name for T, panicking if there is none or more than one.
+func inferEventType[T any]() string {
+
+	names := EventTypesOf[T]()
+
+	switch len(names) {
+	case 0:
+		panic(fmt.Errorf("no gateway event carries %s data", reflect.TypeFor[T]()))
+	case 1:
+		return names[0]
+	default:
+		panic(fmt.Errorf("%s data is shared by events %s; register with OnEvent instead", reflect.TypeFor[T](), strings.Join(names, ", ")))
+	}
+}
+
+// Adapts a typed handler function to a GatewayEventHandler.
+func typedHandler[T any](eventType string, once bool, fn func(context.Context, *App, *T) error) GatewayEventHandler {
+	return GatewayEventHandler{
+		Type: eventType,
+		Once: once,
+		run: func(ctx context.Context, event *gateway.Event, a *App) error {
+
+			data, ok := event.D.(*T)
+			if !ok {
+				return fmt.Errorf("%s event data is %T, expected *%s", eventType, event.D, reflect.TypeFor[T]())
+			}
+
+			return fn(ctx, a, data)
+		},
+	}
 }
@@ -0,0 +1,71 @@
+package discord
+
+import (
+	"context"
+	"io"
+	"log"
+	"testing"
+
+	"brandenly.com/go/packages/discord-bot/common"
+	"brandenly.com/go/packages/discord-bot/gateway"
+)
+
+func TestOnceWithFilters(t *testing.T) {
+
+	message := func(guildId common.Snowflake) gateway.Event {
+		return gateway.NewSyntheticEvent("MESSAGE_CREATE", 0, &gateway.MessageCreate{GuildId: &guildId})
+	}
+
+	tests := []struct {
+		name     string
+		register func(a *App, fn func(context.Context, *App, *gateway.MessageCreate) error)
+	}{
+		{
+			name: "application middleware",
+			register: func(a *App, fn func(context.Context, *App, *gateway.MessageCreate) error) {
+				a.Use(FilterGuilds(2))
+				Once(a, fn)
+			},
+		},
+		{
+			name: "handler middleware",
+			register: func(a *App, fn func(context.Context, *App, *gateway.MessageCreate) error) {
+				handler := typedHandler("MESSAGE_CREATE", true, fn)
+				handler.Middlewares = []Middleware{FilterGuilds(2)}
+				a.AddHandler(handler)
+			},
+		},
+		{
+			name: "set directly on the application",
+			register: func(a *App, fn func(context.Context, *App, *gateway.MessageCreate) error) {
+				handler := typedHandler("MESSAGE_CREATE", true, fn)
+				handler.Middlewares = []Middleware{FilterGuilds(2)}
+				a.GatewayEventHandlers = append(a.GatewayEventHandlers, handler)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			a := &App{Logger: log.New(io.Discard, "", 0)}
+
+			var seen []common.Snowflake
+			test.register(a, func(ctx context.Context, a *App, m *gateway.MessageCreate) error {
+				seen = append(seen, *m.GuildId)
+				return nil
+			})
+
+			for _, guildId := range []common.Snowflake{1, 1, 2, 2, 1} {
+				a.handle(message(guildId))
+			}
+
+			if len(seen) != 1 || seen[0] != 2 {
+				t.Errorf("handler saw guilds %v, want [2]", seen)
+			}
+			if n := len(a.GatewayEventHandlers); n != 0 {
+				t.Errorf("%d handlers still registered after the one-shot handler ran", n)
+			}
+		})
+	}
+}
@@ -0,0 +1,265 @@
+package discord
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"brandenly.com/go/packages/discord-bot/common"
+	"brandenly.com/go/packages/discord-bot/gateway"
+)
+
+// Default time to wait for a shard's guilds to load after READY
+const DefaultGuildsLoadedTimeout = 30 * time.Second
+
+// Tracks guild availability per shard so GUILD_CREATE and GUILD_DELETE can be classified.
+type guildTracker struct {
+	mu     sync.Mutex
+	shards map[int]*shardGuilds
+}
+
+// Guild availability for a single shard.
+type shardGuilds struct {
+	pending     map[common.Snowflake]bool // Guilds listed in READY that have not loaded yet
+	unavailable map[common.Snowflake]bool // Guilds that went unavailable after loading
+	loaded      int                       // Guilds from READY that have loaded
+	done        bool                      // Whether SHARD_GUILDS_LOADED has been sent for the current session
+	session     int                       // Incremented on READY so stale timeouts are ignored
+	timer       *time.Timer               // Announces SHARD_GUILDS_LOADED if the guilds don't all load in time
+}
+
+func (t *guildTracker) shard(index int) *shardGuilds {
+
+	if t.shards == nil {
+		t.shards = map[int]*shardGuilds{}
+	}
+
+	shard, ok := t.shards[index]
+	if !ok {
+		shard = &shardGuilds{pending: map[common.Snowflake]bool{}, unavailable: map[common.Snowflake]bool{}}
+		t.shards[index] = shard
+	}
+
+	return shard
+}
+
+// Stops waiting for every shard's guilds to load, such as when the application stops.
+func (t *guildTracker) stop() {
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, shard := range t.shards {
+		if shard.timer != nil {
+			shard.timer.Stop()
+		}
+	}
+}
+
+// Queues an event read from a shard's connection, followed on the same lane by the guild availability
+// events derived from it. READY and a shard's GUILD_CREATE and GUILD_DELETE events are dispatched on
+// different lanes, so they are classified here, in the order the shard received them, rather than as
+// they are handled.
+func (a *App) enqueue(ctx context.Context, events *dispatcher, event gateway.Event, timeout time.Duration) bool {
+
+	derived := a.observeGuilds(&event)
+	lane := events.lane(&event)
+
+	ok := events.enqueueOn(ctx, lane, event, timeout)
+	for _, guildEvent := range derived {
+		if !events.enqueueOn(ctx, lane, guildEvent, timeout) {
+			a.stats.dropped.Add(1)
+		}
+	}
+
+	return ok
+}
+
+// Derives guild availability events from READY, GUILD_CREATE and GUILD_DELETE.
+func (a *App) observeGuilds(event *gateway.Event) []gateway.Event {
+
+	if event.Op != 0 || event.T == nil {
+		return nil
+	}
+
+	switch data := event.D.(type) {
+
+	case *gateway.Ready:
+		return a.guildsReady(event.Shard, data)
+
+	case *gateway.GuildCreate:
+		return a.guildCreated(event.Shard, data)
+
+	case *common.UnavailableGuild:
+		if *event.T == "GUILD_DELETE" {
+			return a.guildDeleted(event.Shard, data)
+		}
+	}
+
+	return nil
+}
+
+// Starts waiting for the guilds listed in READY.
+func (a *App) guildsReady(index int, ready *gateway.Ready) []gateway.Event {
+
+	a.guilds.mu.Lock()
+	defer a.guilds.mu.Unlock()
+
+	shard := a.guilds.shard(index)
+	if shard.timer != nil {
+		shard.timer.Stop()
+	}
+	shard.session++
+	shard.pending = map[common.Snowflake]bool{}
+	shard.unavailable = map[common.Snowflake]bool{}
+	shard.loaded = 0
+	shard.done = false
+
+	for _, guild := range ready.Guilds {
+		shard.pending[guild.Id] = true
+	}
+
+	if len(shard.pending) == 0 {
+		shard.done = true
+		return []gateway.Event{guildsLoaded(index, shard, false)}
+	}
+
+	timeout := a.GuildsLoadedTimeout
+	if timeout <= 0 {
+		timeout = DefaultGuildsLoadedTimeout
+	}
+
+	session := shard.session
+	shard.timer = time.AfterFunc(timeout, func() { a.guildsTimedOut(index, session) })
+
+	return nil
+}
+
+// Announces SHARD_GUILDS_LOADED for a shard whose guilds did not all load in time.
+func (a *App) guildsTimedOut(index int, session int) {
+
+	a.guilds.mu.Lock()
+
+	shard := a.guilds.shard(index)
+	if shard.session != session || shard.done {
+		a.guilds.mu.Unlock()
+		return
+	}
+
+	shard.done = true
+	event := guildsLoaded(index, shard, true)
+
+	// Guilds still missing are treated as unavailable, so a later GUILD_CREATE counts as recovery
+	for id := range shard.pending {
+		shard.unavailable[id] = true
+	}
+	shard.pending = map[common.Snowflake]bool{}
+
+	a.guilds.mu.Unlock()
+
+	if a.dispatcher != nil {
+		a.dispatcher.enqueue(a.context(), event, 0)
+	} else {
+		a.handle(event)
+	}
+}
+
+// Classifies a GUILD_CREATE as part of the initial load, a recovery or a new join.
+func (a *App) guildCreated(index int, guild *gateway.GuildCreate) []gateway.Event {
+
+	a.guilds.mu.Lock()
+	defer a.guilds.mu.Unlock()
+
+	shard := a.guilds.shard(index)
+
+	if shard.pending[guild.Id] {
+		delete(shard.pending, guild.Id)
+		shard.loaded++
+
+		events := []gateway.Event{
+			gateway.NewSyntheticEvent(gateway.GuildAvailableEvent, index, &gateway.GuildAvailable{
+				Shard:   index,
+				GuildId: guild.Id,
+				Initial: true,
+				Guild:   guild,
+			}),
+		}
+
+		if len(shard.pending) == 0 && !shard.done {
+			shard.done = true
+			events = append(events, guildsLoaded(index, shard, false))
+		}
+
+		return events
+	}
+
+	if shard.unavailable[guild.Id] {
+		delete(shard.unavailable, guild.Id)
+
+		return []gateway.Event{
+			gateway.NewSyntheticEvent(gateway.GuildAvailableEvent, index, &gateway.GuildAvailable{
+				Shard:   index,
+				GuildId: guild.Id,
+				Guild:   guild,
+			}),
+		}
+	}
+
+	return []gateway.Event{
+		gateway.NewSyntheticEvent(gateway.GuildJoinedEvent, index, &gateway.GuildJoined{
+			Shard:   index,
+			GuildId: guild.Id,
+			Guild:   guild,
+		}),
+	}
+}
+
+// Distinguishes an outage from the bot leaving or being removed from a guild.
+func (a *App) guildDeleted(index int, guild *common.UnavailableGuild) []gateway.Event {
+
+	a.guilds.mu.Lock()
+	defer a.guilds.mu.Unlock()
+
+	shard := a.guilds.shard(index)
+
+	if guild.Unavailable {
+		if !shard.pending[guild.Id] {
+			shard.unavailable[guild.Id] = true
+		}
+
+		return []gateway.Event{
+			gateway.NewSyntheticEvent(gateway.GuildUnavailableEvent, index, &gateway.GuildUnavailable{
+				Shard:   index,
+				GuildId: guild.Id,
+			}),
+		}
+	}
+
+	delete(shard.pending, guild.Id)
+	delete(shard.unavailable, guild.Id)
+
+	return []gateway.Event{
+		gateway.NewSyntheticEvent(gateway.GuildRemovedEvent, index, &gateway.GuildRemoved{
+			Shard:   index,
+			GuildId: guild.Id,
+		}),
+	}
+}
+
+// Builds a SHARD_GUILDS_LOADED event from a shard's current state.
+func guildsLoaded(index int, shard *shardGuilds, timedOut bool) gateway.Event {
+
+	unavailable := make([]common.Snowflake, 0, len(shard.pending))
+	for id := range shard.pending {
+		unavailable = append(unavailable, id)
+	}
+	slices.Sort(unavailable)
+
+	return gateway.NewSyntheticEvent(gateway.ShardGuildsLoadedEvent, index, &gateway.ShardGuildsLoaded{
+		Shard:       index,
+		Loaded:      shard.loaded,
+		Unavailable: unavailable,
+		TimedOut:    timedOut,
+	})
+}
@@ -0,0 +1,49 @@
+package discord
+
+import (
+	"slices"
+
+	"brandenly.com/go/packages/discord-bot/gateway"
+)
+
+// Returns the intents the application identified with, or zero before it has started.
+func (a *App) Intents() gateway.Intents {
+	return a.intents
+}
+
+// Logs warnings about registered handlers that the requested intents will never deliver events to,
+// and about privileged intents that must be enabled in the Developer Portal.
+func (a *App) checkIntents() {
+
+	a.handlersMu.Lock()
+	var eventTypes []string
+	for _, handler := range a.GatewayEventHandlers {
+		if !slices.Contains(eventTypes, handler.Type) {
+			eventTypes = append(eventTypes, handler.Type)
+		}
+	}
+	a.handlersMu.Unlock()
+
+	slices.Sort(eventTypes)
+
+	for _, eventType := range eventTypes {
+
+		required, ok := gateway.EventIntents[eventType]
+		if ok && !a.intents.HasAny(required) {
+			a.Logger.Printf("Warning: %s handlers will never run; the event requires one of the intents %s, but only %s were requested", eventType, required, a.intents)
+		}
+
+		if (eventType == "MESSAGE_CREATE" || eventType == "MESSAGE_UPDATE" || eventType == gateway.MessageEditedEvent) && !a.intents.Has(gateway.IntentMessageContent) {
+			a.Logger.Printf("Warning: %s handlers will receive empty content, embeds, attachments and components for most messages; request the MESSAGE_CONTENT intent to receive them", eventType)
+		}
+	}
+
+	if privileged := a.intents.Privileged(); privileged != 0 {
+		a.Logger.Printf("Requesting privileged intents %s; these must be enabled for the application in the Developer Portal or every connection will close with 4014", privileged)
+	}
+}
+
+// Explains a 4014 close, which Discord sends when the requested privileged intents are not enabled.
+func (a *App) explainDisallowedIntents(shard int) {
+	a.Logger.Printf("Shard %d was closed with 4014 (Disallowed intent(s)): the application requested the privileged intents %s, which are not all enabled for it. Enable them under Bot > Privileged Gateway Intents in the Developer Portal (verified bots must be approved for them), or stop requesting them", shard, a.intents.Privileged())
+}
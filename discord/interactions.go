@@ -0,0 +1,74 @@
+package discord
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"brandenly.com/go/packages/discord-bot/common"
+)
+
+// Responds to an interaction. Interactions must be responded to within three seconds, after which
+// the token can only be used to edit or follow up on a deferred response.
+// External reference: https://discord.com/developers/docs/interactions/receiving-and-responding#create-interaction-response
+func (a *App) RespondToInteraction(interactionId common.Snowflake, token string, response common.InteractionResponse) error {
+
+	_, err := a.request("POST", fmt.Sprintf("%s/interactions/%s/%s/callback", ApiBaseUrl, interactionId, token), response)
+	if err != nil {
+		return fmt.Errorf("unable to respond to interaction %s: %w", interactionId, err)
+	}
+
+	return nil
+}
+
+// Edits the original response to an interaction, such as to replace a deferred response.
+// External reference: https://discord.com/developers/docs/interactions/receiving-and-responding#edit-original-interaction-response
+func (a *App) EditInteractionResponse(token string, data common.InteractionCallbackData) error {
+
+	_, err := a.request("PATCH", fmt.Sprintf("%s/webhooks/%s/%s/messages/@original", ApiBaseUrl, a.Id, token), data)
+	if err != nil {
+		return fmt.Errorf("unable to edit interaction response: %w", err)
+	}
+
+	return nil
+}
+
+// Replaces the app's global commands, or a guild's commands when guildId is set, with commands.
+// Returns the registered commands.
+// External reference: https://discord.com/developers/docs/interactions/application-commands#bulk-overwrite-global-application-commands
+func (a *App) OverwriteApplicationCommands(guildId *common.Snowflake, commands []common.ApplicationCommand) ([]common.ApplicationCommand, error) {
+
+	url := fmt.Sprintf("%s/applications/%s/commands", ApiBaseUrl, a.Id)
+	if guildId != nil {
+		url = fmt.Sprintf("%s/applications/%s/guilds/%s/commands", ApiBaseUrl, a.Id, *guildId)
+	}
+
+	data, err := a.request("PUT", url, commands)
+	if err != nil {
+		return nil, fmt.Errorf("unable to overwrite application commands: %w", err)
+	}
+
+	var registered []common.ApplicationCommand
+	if err := json.Unmarshal(*data, &registered); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal registered application commands: %w", err)
+	}
+
+	return registered, nil
+}
+
+// Makes a REST request with body encoded as JSON.
+func (a *App) request(method string, url string, body any) (*[]byte, error) {
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal request body: %w", err)
+	}
+
+	req, err := http.NewRequest(method, url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("unable to create request: %w", err)
+	}
+
+	return a.Make(req)
+}
@@ -0,0 +1,119 @@
+package discord
+
+import (
+	"sync"
+
+	"brandenly.com/go/packages/discord-bot/gateway"
+)
+
+// Tracks which shards have become ready so ALL_SHARDS_READY can be announced once.
+type shardTracker struct {
+	mu         sync.Mutex
+	ready      map[int]bool
+	shardCount int
+	allReady   chan struct{}
+	announced  bool
+}
+
+// Returns a channel that is closed once every shard has received READY.
+func (a *App) AllShardsReady() <-chan struct{} {
+	a.shards.mu.Lock()
+	defer a.shards.mu.Unlock()
+
+	return a.shards.readyChannel()
+}
+
+func (t *shardTracker) readyChannel() chan struct{} {
+	if t.allReady == nil {
+		t.allReady = make(chan struct{})
+	}
+	return t.allReady
+}
+
+// Derives lifecycle events from gateway events that change a shard's session state, returning them
+// in the order they should be dispatched.
+func (a *App) observeLifecycle(event *gateway.Event) []gateway.Event {
+
+	// Invalid session
+	if event.Op == 9 {
+		resumable, _ := event.D.(bool)
+		return []gateway.Event{
+			gateway.NewSyntheticEvent(gateway.ShardInvalidSessionEvent, event.Shard, &gateway.ShardInvalidSession{
+				Shard:     event.Shard,
+				Resumable: resumable,
+			}),
+		}
+	}
+
+	if event.Op != 0 || event.T == nil {
+		return nil
+	}
+
+	switch *event.T {
+
+	case gateway.ShardDisconnectedEvent:
+		if disconnected, ok := event.D.(*gateway.ShardDisconnected); ok && disconnected.CloseCode == 4014 {
+			a.explainDisallowedIntents(disconnected.Shard)
+		}
+
+	case "RESUMED":
+		return []gateway.Event{
+			gateway.NewSyntheticEvent(gateway.ShardResumedEvent, event.Shard, &gateway.ShardResumed{Shard: event.Shard}),
+		}
+
+	case "READY":
+		ready, ok := event.D.(*gateway.Ready)
+		if !ok {
+			return nil
+		}
+
+		// Prefer the shard information Discord echoes back, it is also present in replays
+		shard, shardCount := event.Shard, len(a.gatewayConnections)
+		if ready.Shard[1] > 0 {
+			shard, shardCount = ready.Shard[0], ready.Shard[1]
+		}
+		shardCount = max(shardCount, 1)
+
+		events := []gateway.Event{
+			gateway.NewSyntheticEvent(gateway.ShardReadyEvent, shard, &gateway.ShardReady{
+				Shard:      shard,
+				ShardCount: shardCount,
+				SessionId:  ready.SessionId,
+				Guilds:     len(ready.Guilds),
+			}),
+		}
+
+		if a.markShardReady(shard, shardCount) {
+			events = append(events, gateway.NewSyntheticEvent(gateway.AllShardsReadyEvent, shard, &gateway.AllShardsReady{
+				ShardCount: shardCount,
+			}))
+		}
+
+		return events
+	}
+
+	return nil
+}
+
+// Records a ready shard, returning true the first time every shard is ready.
+func (a *App) markShardReady(shard int, shardCount int) bool {
+
+	a.shards.mu.Lock()
+	defer a.shards.mu.Unlock()
+
+	if a.shards.ready == nil {
+		a.shards.ready = map[int]bool{}
+	}
+
+	a.shards.ready[shard] = true
+	a.shards.shardCount = shardCount
+
+	if a.shards.announced || len(a.shards.ready) < shardCount {
+		return false
+	}
+
+	a.shards.announced = true
+	close(a.shards.readyChannel())
+
+	return true
+}
@@ -0,0 +1,28 @@
+package discord
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"brandenly.com/go/packages/discord-bot/common"
+)
+
+// Maximum length of a message's content
+const MaxMessageLength = 2000
+
+// Posts a message to a channel. Returns the created message.
+// External reference: https://discord.com/developers/docs/resources/message#create-message
+func (a *App) CreateMessage(channelId common.Snowflake, params common.MessageParams) (common.Message, error) {
+
+	data, err := a.request("POST", fmt.Sprintf("%s/channels/%s/messages", ApiBaseUrl, channelId), params)
+	if err != nil {
+		return common.Message{}, fmt.Errorf("unable to create message in channel %s: %w", channelId, err)
+	}
+
+	var message common.Message
+	if err := json.Unmarshal(*data, &message); err != nil {
+		return common.Message{}, fmt.Errorf("unable to unmarshal created message: %w", err)
+	}
+
+	return message, nil
+}
@@ -0,0 +1,260 @@
+package discord
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"slices"
+	"sync"
+	"time"
+
+	"brandenly.com/go/packages/discord-bot/common"
+	"brandenly.com/go/packages/discord-bot/gateway"
+)
+
+// Executes an event handler for a dispatch event.
+type HandlerFunc func(ctx context.Context, event *gateway.Event, a *App) error
+
+// Wraps the execution of an event handler. A middleware may short circuit dispatch by returning
+// without calling next.
+type Middleware func(next HandlerFunc) HandlerFunc
+
+// Adds middlewares that wrap every event handler, in the order given. Middlewares should be added
+// before the application is started.
+func (a *App) Use(middlewares ...Middleware) {
+	a.Middlewares = append(a.Middlewares, middlewares...)
+}
+
+// Builds the execution chain for a handler: panic recovery, then the application's middlewares, then
+// the handler's own middlewares.
+func (a *App) chain(handler GatewayEventHandler) HandlerFunc {
+
+	fn := handler.run
+	if fn == nil {
+		fn = func(ctx context.Context, event *gateway.Event, a *App) error {
+			return handler.Fn(event, a)
+		}
+	}
+
+	if handler.Once {
+		fn = a.runOnce(handler, fn)
+	}
+
+	for _, middleware := range slices.Backward(handler.Middlewares) {
+		fn = middleware(fn)
+	}
+
+	for _, middleware := range slices.Backward(a.Middlewares) {
+		fn = middleware(fn)
+	}
+
+	return Recover()(fn)
+}
+
+// Reports handler errors through the application's error handler, or logs them if it has none.
+func (a *App) handlerError(event *gateway.Event, err error) {
+
+	if a.ErrorHandler != nil {
+		a.ErrorHandler(event, err)
+		return
+	}
+
+	name := OpCodes[event.Op]
+	if event.T != nil {
+		name = *event.T
+	}
+
+	if panicErr, ok := err.(*PanicError); ok {
+		a.Logger.Printf("%s handler panicked: %v\n%s", name, panicErr.Value, panicErr.Stack)
+		return
+	}
+
+	a.Logger.Printf("error occurred while executing %s event handler: %s", name, err.Error())
+}
+
+//// Panic recovery
+
+// Returned in place of a handler's result when the handler panics.
+type PanicError struct {
+	Value any    // Value passed to panic
+	Stack []byte // Stack trace of the panicking goroutine
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("handler panicked: %v", e.Value)
+}
+
+// Recovers from handler panics, converting them into a *PanicError. Applied to every handler by default.
+func Recover() Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, event *gateway.Event, a *App) (err error) {
+
+			defer func() {
+				if value := recover(); value != nil {
+					err = &PanicError{Value: value, Stack: debug.Stack()}
+				}
+			}()
+
+			return next(ctx, event, a)
+		}
+	}
+}
+
+// Runs a dispatch step outside the handler chain, such as a state update, reporting a panic through
+// the application's error handler as a *PanicError instead of crashing the application.
+func (a *App) guard(event *gateway.Event, fn func()) {
+
+	defer func() {
+		if value := recover(); value != nil {
+			a.handlerError(event, &PanicError{Value: value, Stack: debug.Stack()})
+		}
+	}()
+
+	fn()
+}
+
+//// Timeouts
+
+// Bounds each handler execution by d. The handler's context is cancelled once d elapses and dispatch
+// moves on, returning context.DeadlineExceeded; handlers should watch ctx to stop their work early.
+func Timeout(d time.Duration) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, event *gateway.Event, a *App) error {
+
+			ctx, cancel := context.WithTimeout(ctx, d)
+			defer cancel()
+
+			result := make(chan error, 1)
+
+			go func() {
+				result <- Recover()(next)(ctx, event, a)
+			}()
+
+			select {
+			case err := <-result:
+				return err
+			case <-ctx.Done():
+				return fmt.Errorf("%s handler did not finish within %s: %w", *event.T, d, ctx.Err())
+			}
+		}
+	}
+}
+
+//// Metrics
+
+// Execution counters for the handlers of a single event type.
+type HandlerStats struct {
+	Calls   uint64        // Handler executions
+	Errors  uint64        // Executions that returned an error, including panics
+	Panics  uint64        // Executions that panicked
+	Total   time.Duration // Combined execution time
+	Slowest time.Duration // Longest single execution
+}
+
+// Collects handler timing and error counters by event type; safe for concurrent use.
+type HandlerMetrics struct {
+	mu    sync.Mutex
+	stats map[string]HandlerStats
+}
+
+// Returns a copy of the collected counters keyed by event type.
+func (m *HandlerMetrics) Snapshot() map[string]HandlerStats {
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshot := make(map[string]HandlerStats, len(m.stats))
+	for eventType, stats := range m.stats {
+		snapshot[eventType] = stats
+	}
+
+	return snapshot
+}
+
+func (m *HandlerMetrics) record(eventType string, elapsed time.Duration, err error) {
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.stats == nil {
+		m.stats = map[string]HandlerStats{}
+	}
+
+	stats := m.stats[eventType]
+	stats.Calls++
+	stats.Total += elapsed
+	stats.Slowest = max(stats.Slowest, elapsed)
+
+	if err != nil {
+		stats.Errors++
+		if _, ok := err.(*PanicError); ok {
+			stats.Panics++
+		}
+	}
+
+	m.stats[eventType] = stats
+}
+
+// Records the duration and outcome of every handler execution into metrics.
+func Instrument(metrics *HandlerMetrics) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, event *gateway.Event, a *App) error {
+
+			started := time.Now()
+			err := Recover()(next)(ctx, event, a)
+			metrics.record(*event.T, time.Since(started), err)
+
+			return err
+		}
+	}
+}
+
+//// Filters
+
+// Only runs handlers for events matching keep; other events are skipped without error.
+func Filter(keep func(event *gateway.Event) bool) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, event *gateway.Event, a *App) error {
+			if !keep(event) {
+				return nil
+			}
+			return next(ctx, event, a)
+		}
+	}
+}
+
+// Only runs handlers for events from the given guilds.
+func FilterGuilds(guildIds ...common.Snowflake) Middleware {
+	return Filter(func(event *gateway.Event) bool {
+		return slices.Contains(guildIds, event.GuildId())
+	})
+}
+
+// Only runs handlers for events from the given channels.
+func FilterChannels(channelIds ...common.Snowflake) Middleware {
+	return Filter(func(event *gateway.Event) bool {
+		return slices.Contains(channelIds, event.ChannelId())
+	})
+}
+
+// Only runs handlers for events triggered by the given users.
+func FilterUsers(userIds ...common.Snowflake) Middleware {
+	return Filter(func(event *gateway.Event) bool {
+		return slices.Contains(userIds, event.UserId())
+	})
+}
+
+// Skips events from the given guilds.
+func IgnoreGuilds(guildIds ...common.Snowflake) Middleware {
+	return Filter(func(event *gateway.Event) bool {
+		return !slices.Contains(guildIds, event.GuildId())
+	})
+}
+
+// Skips events triggered by bot users.
+func IgnoreBots() Middleware {
+	return Filter(func(event *gateway.Event) bool {
+		user := event.User()
+		return user == nil || user.Bot == nil || !*user.Bot
+	})
+}
@@ -0,0 +1,90 @@
+package discord
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	"brandenly.com/go/packages/discord-bot/gateway"
+)
+
+// Feeds a recording created by a gateway.Recorder back through the application's dispatch pipeline
+// without opening any gateway connections.
+//
+// Speed controls the pacing of the replay: 1 reproduces the original timing, 2 replays twice as fast,
+// and 0 (or any negative value) replays every frame as fast as the handlers allow.
+func (a *App) Replay(ctx context.Context, recording io.Reader, speed float64) error {
+
+	// Replays may run without Start() having been called
+	if a.ctx == nil {
+		a.ctx = ctx
+	}
+
+	if a.Logger == nil {
+		a.Logger = log.New(os.Stdout, "[replay]", log.LstdFlags|log.Lshortfile)
+	}
+
+	if a.SlowHandler <= 0 {
+		a.SlowHandler = DefaultSlowHandlerThreshold
+	}
+
+	// Dispatch through the running application's lanes, or through temporary lanes when replaying
+	// standalone or after the application stopped receiving
+	events := a.dispatcher
+	if !a.receiving.Load() {
+		events = newDispatcher(a.DispatchWorkers, a.DispatchQueueSize, a.dispatch)
+		defer events.close()
+	}
+
+	var previous time.Time
+
+	return gateway.ReadRecording(recording, func(frame gateway.RecordedFrame) error {
+
+		// Wait out the original gap between frames
+		if speed > 0 && !previous.IsZero() {
+			delay := time.Duration(float64(frame.Time.Sub(previous)) / speed)
+			if delay > 0 {
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-time.After(delay):
+				}
+			}
+		}
+		previous = frame.Time
+
+		var event gateway.Event
+		if err := json.Unmarshal(frame.Data, &event); err != nil {
+			a.Logger.Printf("Skipping recorded frame from shard %d: %s", frame.Shard, err.Error())
+			return nil
+		}
+
+		event.Shard = frame.Shard
+
+		if !a.enqueue(ctx, events, event, 0) {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			return errors.New("dispatcher closed: the application stopped receiving before the replay finished")
+		}
+
+		return nil
+	})
+}
+
+// Opens the recording at path and replays it. See App.Replay.
+func (a *App) ReplayFile(ctx context.Context, path string, speed float64) error {
+
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("unable to open recording: %w", err)
+	}
+	defer file.Close()
+
+	return a.Replay(ctx, file, speed)
+}
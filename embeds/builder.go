@@ -0,0 +1,196 @@
+package embeds
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"brandenly.com/go/packages/discord-bot/common"
+)
+
+// Builds a rich embed. Setters can be chained, and Build reports every way the embed breaks
+// Discord's limits, unless the builder truncates.
+type Builder struct {
+	embed    common.Embed
+	truncate bool
+	errs     []error
+}
+
+// Returns an empty embed builder.
+func New() *Builder {
+	return &Builder{}
+}
+
+// Returns a builder starting from a copy of embed.
+func From(embed common.Embed) *Builder {
+	return &Builder{embed: clone(embed)}
+}
+
+// Sets whether Build and Split shorten text over its limit instead of reporting it; see Truncate.
+func (b *Builder) Truncate(truncate bool) *Builder {
+	b.truncate = truncate
+	return b
+}
+
+//// Content
+
+// Sets the title.
+func (b *Builder) Title(title string) *Builder {
+	b.embed.Title = title
+	return b
+}
+
+// Sets the URL the title links to.
+func (b *Builder) Url(url string) *Builder {
+	b.embed.Url = url
+	return b
+}
+
+// Sets the description.
+func (b *Builder) Description(description string) *Builder {
+	b.embed.Description = description
+	return b
+}
+
+// Adds a field.
+func (b *Builder) Field(name string, value string) *Builder {
+	return b.field(name, value, false)
+}
+
+// Adds a field displayed side by side with neighbouring inline fields.
+func (b *Builder) InlineField(name string, value string) *Builder {
+	return b.field(name, value, true)
+}
+
+func (b *Builder) field(name string, value string, inline bool) *Builder {
+
+	if b.embed.Fields == nil {
+		b.embed.Fields = &[]common.EmbedField{}
+	}
+
+	field := common.EmbedField{Name: name, Value: value}
+	if inline {
+		field.Inline = &inline
+	}
+
+	*b.embed.Fields = append(*b.embed.Fields, field)
+	return b
+}
+
+// Sets the footer, with an optional icon.
+func (b *Builder) Footer(text string, iconUrl string) *Builder {
+	b.embed.Footer = &common.EmbedFooter{Text: text, IconUrl: optional(iconUrl)}
+	return b
+}
+
+// Sets the author, with an optional link and icon.
+func (b *Builder) Author(name string, url string, iconUrl string) *Builder {
+	b.embed.Author = &common.EmbedAuthor{Name: name, Url: optional(url), IconUrl: optional(iconUrl)}
+	return b
+}
+
+// Sets the large image shown below the content.
+func (b *Builder) Image(url string) *Builder {
+	b.embed.Image = &common.EmbedImage{Url: url}
+	return b
+}
+
+// Sets the small image shown beside the content.
+func (b *Builder) Thumbnail(url string) *Builder {
+	b.embed.Thumbnail = &common.EmbedThumbnail{Url: url}
+	return b
+}
+
+// Sets the timestamp shown in the footer.
+func (b *Builder) Timestamp(timestamp time.Time) *Builder {
+	b.embed.Timestamp = &timestamp
+	return b
+}
+
+// Sets the timestamp to the current time.
+func (b *Builder) Now() *Builder {
+	return b.Timestamp(time.Now())
+}
+
+//// Color
+
+// Sets the color of the embed's left border, as 0xRRGGBB.
+func (b *Builder) Color(color int) *Builder {
+	b.embed.Color = color
+	return b
+}
+
+// Sets the color from its red, green and blue components.
+func (b *Builder) ColorRGB(red uint8, green uint8, blue uint8) *Builder {
+	return b.Color(RGB(red, green, blue))
+}
+
+// Sets the color from a hex code such as "#5865F2", "5865F2", "0x5865F2" or "#FFF". An invalid code
+// is reported by Build.
+func (b *Builder) ColorHex(hex string) *Builder {
+
+	color, err := ParseColor(hex)
+	if err != nil {
+		b.errs = append(b.errs, err)
+		return b
+	}
+
+	return b.Color(color)
+}
+
+// Returns the color made of red, green and blue components.
+func RGB(red uint8, green uint8, blue uint8) int {
+	return int(red)<<16 | int(green)<<8 | int(blue)
+}
+
+// Parses a hex color code such as "#5865F2", "5865F2", "0x5865F2" or "#FFF".
+func ParseColor(hex string) (int, error) {
+
+	digits := strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(hex), "#"), "0x")
+
+	if len(digits) == 3 {
+		digits = string([]byte{digits[0], digits[0], digits[1], digits[1], digits[2], digits[2]})
+	}
+
+	color, err := strconv.ParseUint(digits, 16, 32)
+	if len(digits) != 6 || err != nil {
+		return 0, fmt.Errorf("invalid hex color %q", hex)
+	}
+
+	return int(color), nil
+}
+
+//// Building
+
+// Reports every way the embed breaks Discord's limits. When the builder truncates, only problems
+// truncation can't fix, such as a field without a value, are reported.
+func (b *Builder) Validate() error {
+	_, err := b.Build()
+	return err
+}
+
+// Builds the embed.
+func (b *Builder) Build() (common.Embed, error) {
+
+	embed := clone(b.embed)
+	if b.truncate {
+		embed = Truncate(embed)
+	}
+
+	if err := errors.Join(append(append([]error(nil), b.errs...), Validate(embed))...); err != nil {
+		return common.Embed{}, err
+	}
+
+	return embed, nil
+}
+
+func optional(value string) *string {
+
+	if value == "" {
+		return nil
+	}
+
+	return &value
+}
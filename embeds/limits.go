@@ -0,0 +1,264 @@
+package embeds
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"brandenly.com/go/packages/discord-bot/common"
+)
+
+// Embed limits. Lengths are counted in characters, and the total of an embed's title, description,
+// field names and values, footer text and author name, across every embed of a message, can't exceed
+// MaxTotalLength.
+// External reference: https://discord.com/developers/docs/resources/message#embed-object-embed-limits
+const (
+	MaxTitleLength       = 256  // Maximum length of a title
+	MaxDescriptionLength = 4096 // Maximum length of a description
+	MaxFields            = 25   // Maximum fields in an embed
+	MaxFieldNameLength   = 256  // Maximum length of a field name
+	MaxFieldValueLength  = 1024 // Maximum length of a field value
+	MaxFooterTextLength  = 2048 // Maximum length of footer text
+	MaxAuthorNameLength  = 256  // Maximum length of an author name
+	MaxTotalLength       = 6000 // Maximum combined length of the embeds in a message
+	MaxEmbedsPerMessage  = 10   // Maximum embeds in a message
+)
+
+// Ends text shortened to fit a limit
+const Ellipsis = "…"
+
+// Returns the number of characters of embed that count towards MaxTotalLength.
+func Length(embed common.Embed) int {
+
+	n := utf8.RuneCountInString(embed.Title) + utf8.RuneCountInString(embed.Description)
+
+	if embed.Fields != nil {
+		for _, field := range *embed.Fields {
+			n += utf8.RuneCountInString(field.Name) + utf8.RuneCountInString(field.Value)
+		}
+	}
+
+	if embed.Footer != nil {
+		n += utf8.RuneCountInString(embed.Footer.Text)
+	}
+
+	if embed.Author != nil {
+		n += utf8.RuneCountInString(embed.Author.Name)
+	}
+
+	return n
+}
+
+//// Validation
+
+// Reports every way embed breaks Discord's limits.
+func Validate(embed common.Embed) error {
+
+	errs := checkLength("title", embed.Title, MaxTitleLength)
+	errs = append(errs, checkLength("description", embed.Description, MaxDescriptionLength)...)
+
+	if embed.Fields != nil {
+
+		if n := len(*embed.Fields); n > MaxFields {
+			errs = append(errs, fmt.Errorf("%d fields, more than the maximum of %d", n, MaxFields))
+		}
+
+		for i, field := range *embed.Fields {
+
+			if field.Name == "" || field.Value == "" {
+				errs = append(errs, fmt.Errorf("field %d: fields need a name and a value", i+1))
+			}
+
+			errs = append(errs, within(fmt.Sprintf("field %d", i+1), checkLength("name", field.Name, MaxFieldNameLength))...)
+			errs = append(errs, within(fmt.Sprintf("field %d", i+1), checkLength("value", field.Value, MaxFieldValueLength))...)
+		}
+	}
+
+	if embed.Footer != nil {
+		if embed.Footer.Text == "" {
+			errs = append(errs, fmt.Errorf("footers need text"))
+		}
+		errs = append(errs, checkLength("footer text", embed.Footer.Text, MaxFooterTextLength)...)
+	}
+
+	if embed.Author != nil {
+		if embed.Author.Name == "" {
+			errs = append(errs, fmt.Errorf("authors need a name"))
+		}
+		errs = append(errs, checkLength("author name", embed.Author.Name, MaxAuthorNameLength)...)
+	}
+
+	if n := Length(embed); n > MaxTotalLength {
+		errs = append(errs, fmt.Errorf("embed is %d characters in total, more than the maximum of %d", n, MaxTotalLength))
+	}
+
+	return errors.Join(errs...)
+}
+
+// Reports every way embeds, sent together in one message, break Discord's limits.
+func ValidateMessage(embeds []common.Embed) error {
+
+	var errs []error
+
+	if len(embeds) > MaxEmbedsPerMessage {
+		errs = append(errs, fmt.Errorf("%d embeds, more than the maximum of %d", len(embeds), MaxEmbedsPerMessage))
+	}
+
+	total := 0
+	for i, embed := range embeds {
+		total += Length(embed)
+		errs = append(errs, within(fmt.Sprintf("embed %d", i+1), []error{Validate(embed)})...)
+	}
+
+	if len(embeds) > 1 && total > MaxTotalLength {
+		errs = append(errs, fmt.Errorf("embeds are %d characters in total, more than the maximum of %d", total, MaxTotalLength))
+	}
+
+	return errors.Join(errs...)
+}
+
+//// Truncation
+
+// Returns a copy of embed shortened to fit Discord's limits. Text over its limit is cut short and
+// ends with Ellipsis, and fields beyond MaxFields are dropped. If the embed is still over
+// MaxTotalLength, fields are dropped from the end, then the description, footer, author name and
+// title are shortened.
+func Truncate(embed common.Embed) common.Embed {
+
+	embed = clone(embed)
+
+	embed.Title = truncate(embed.Title, MaxTitleLength)
+	embed.Description = truncate(embed.Description, MaxDescriptionLength)
+
+	if embed.Fields != nil {
+
+		fields := *embed.Fields
+		if len(fields) > MaxFields {
+			fields = fields[:MaxFields]
+		}
+
+		for i := range fields {
+			fields[i].Name = truncate(fields[i].Name, MaxFieldNameLength)
+			fields[i].Value = truncate(fields[i].Value, MaxFieldValueLength)
+		}
+
+		*embed.Fields = fields
+	}
+
+	if embed.Footer != nil {
+		embed.Footer.Text = truncate(embed.Footer.Text, MaxFooterTextLength)
+	}
+
+	if embed.Author != nil {
+		embed.Author.Name = truncate(embed.Author.Name, MaxAuthorNameLength)
+	}
+
+	excess := Length(embed) - MaxTotalLength
+	if excess <= 0 {
+		return embed
+	}
+
+	for excess > 0 && embed.Fields != nil && len(*embed.Fields) > 0 {
+		fields := *embed.Fields
+		last := fields[len(fields)-1]
+		excess -= utf8.RuneCountInString(last.Name) + utf8.RuneCountInString(last.Value)
+		*embed.Fields = fields[:len(fields)-1]
+	}
+
+	if excess > 0 {
+		excess -= shorten(&embed.Description, excess)
+	}
+
+	if excess > 0 && embed.Footer != nil {
+		excess -= shorten(&embed.Footer.Text, excess)
+	}
+
+	if excess > 0 && embed.Author != nil {
+		excess -= shorten(&embed.Author.Name, excess)
+	}
+
+	if excess > 0 {
+		shorten(&embed.Title, excess)
+	}
+
+	return embed
+}
+
+// Returns text cut to at most max characters, ending with Ellipsis if it was cut.
+func truncate(text string, max int) string {
+
+	if utf8.RuneCountInString(text) <= max {
+		return text
+	}
+
+	if max <= utf8.RuneCountInString(Ellipsis) {
+		return ""
+	}
+
+	runes := []rune(text)[:max-utf8.RuneCountInString(Ellipsis)]
+	return strings.TrimRightFunc(string(runes), isSpace) + Ellipsis
+}
+
+// Shortens text by at least n characters, and returns how many characters it was shortened by.
+func shorten(text *string, n int) int {
+
+	before := utf8.RuneCountInString(*text)
+	*text = truncate(*text, before-n)
+
+	return before - utf8.RuneCountInString(*text)
+}
+
+// Returns a copy of embed that shares no fields, footer or author with it.
+func clone(embed common.Embed) common.Embed {
+
+	if embed.Fields != nil {
+		fields := append([]common.EmbedField(nil), *embed.Fields...)
+		embed.Fields = &fields
+	}
+
+	if embed.Footer != nil {
+		footer := *embed.Footer
+		embed.Footer = &footer
+	}
+
+	if embed.Author != nil {
+		author := *embed.Author
+		embed.Author = &author
+	}
+
+	return embed
+}
+
+//// Checks
+
+func checkLength(field string, value string, max int) []error {
+
+	if n := utf8.RuneCountInString(value); n > max {
+		return []error{fmt.Errorf("%s is %d characters, more than the maximum of %d", field, n, max)}
+	}
+
+	return nil
+}
+
+// Returns errs, and the errors joined in them, each prefixed with context.
+func within(context string, errs []error) []error {
+
+	prefixed := make([]error, 0, len(errs))
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			prefixed = append(prefixed, within(context, joined.Unwrap())...)
+			continue
+		}
+		prefixed = append(prefixed, fmt.Errorf("%s: %w", context, err))
+	}
+
+	return prefixed
+}
+
+func isSpace(r rune) bool {
+	return r == ' ' || r == '\n' || r == '\t'
+}
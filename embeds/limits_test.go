@@ -0,0 +1,112 @@
+package embeds
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"brandenly.com/go/packages/discord-bot/common"
+)
+
+func TestTruncate(t *testing.T) {
+
+	field := func(name string, value string) common.EmbedField {
+		return common.EmbedField{Name: name, Value: value}
+	}
+
+	tests := []struct {
+		name  string
+		embed common.Embed
+		check func(t *testing.T, embed common.Embed)
+	}{
+		{
+			name:  "within limits is unchanged",
+			embed: common.Embed{Title: "title", Description: "description"},
+			check: func(t *testing.T, embed common.Embed) {
+				if embed.Title != "title" || embed.Description != "description" {
+					t.Errorf("got %q, %q", embed.Title, embed.Description)
+				}
+			},
+		},
+		{
+			name:  "long title ends with an ellipsis",
+			embed: common.Embed{Title: strings.Repeat("é", MaxTitleLength+10)},
+			check: func(t *testing.T, embed common.Embed) {
+				if n := utf8.RuneCountInString(embed.Title); n != MaxTitleLength {
+					t.Errorf("title is %d characters, want %d", n, MaxTitleLength)
+				}
+				if !strings.HasSuffix(embed.Title, Ellipsis) {
+					t.Errorf("title %q does not end with %q", embed.Title, Ellipsis)
+				}
+			},
+		},
+		{
+			name: "fields beyond the maximum are dropped",
+			embed: common.Embed{Fields: func() *[]common.EmbedField {
+				fields := make([]common.EmbedField, MaxFields+1)
+				for i := range fields {
+					fields[i] = field(strconv.Itoa(i), "value")
+				}
+				return &fields
+			}()},
+			check: func(t *testing.T, embed common.Embed) {
+				if n := len(*embed.Fields); n != MaxFields {
+					t.Errorf("got %d fields, want %d", n, MaxFields)
+				}
+			},
+		},
+		{
+			name: "over the total drops fields before shortening the description",
+			embed: common.Embed{
+				Description: strings.Repeat("d", 3000),
+				Fields: &[]common.EmbedField{
+					field("a", strings.Repeat("v", 1000)),
+					field("b", strings.Repeat("v", 1000)),
+					field("c", strings.Repeat("v", 1000)),
+					field("d", strings.Repeat("v", 1000)),
+				},
+			},
+			check: func(t *testing.T, embed common.Embed) {
+				if n := len(*embed.Fields); n != 2 {
+					t.Errorf("got %d fields, want 2", n)
+				}
+				if n := utf8.RuneCountInString(embed.Description); n != 3000 {
+					t.Errorf("description is %d characters, want 3000", n)
+				}
+			},
+		},
+		{
+			name: "over the total without fields shortens the description",
+			embed: common.Embed{
+				Description: strings.Repeat("d", MaxDescriptionLength),
+				Footer:      &common.EmbedFooter{Text: strings.Repeat("f", MaxFooterTextLength)},
+			},
+			check: func(t *testing.T, embed common.Embed) {
+				if embed.Footer.Text != strings.Repeat("f", MaxFooterTextLength) {
+					t.Errorf("footer was shortened before the description")
+				}
+				if !strings.HasSuffix(embed.Description, Ellipsis) {
+					t.Errorf("description does not end with %q", Ellipsis)
+				}
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			before := Length(test.embed)
+			truncated := Truncate(test.embed)
+
+			if err := Validate(truncated); err != nil {
+				t.Errorf("truncated embed is invalid: %v", err)
+			}
+			if Length(test.embed) != before {
+				t.Errorf("Truncate modified its argument")
+			}
+
+			test.check(t, truncated)
+		})
+	}
+}
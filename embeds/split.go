@@ -0,0 +1,196 @@
+package embeds
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"brandenly.com/go/packages/discord-bot/common"
+)
+
+// Name given to the fields continuing a field value too long for one field
+const ContinuedFieldName = "\u200b"
+
+// Builds the embed, splitting content that doesn't fit into one embed across several. The
+// description is split between paragraphs, lines or words, field values over MaxFieldValueLength
+// continue in unnamed fields, and fields continue in further embeds past MaxFields or
+// MaxTotalLength. The first embed keeps the title, author and thumbnail, the last the footer,
+// timestamp and image, and every embed the color. Pass the result to Messages to group it into
+// messages.
+func (b *Builder) Split() ([]common.Embed, error) {
+
+	if len(b.errs) > 0 {
+		return nil, errors.Join(b.errs...)
+	}
+
+	source := clone(b.embed)
+	if b.truncate {
+		source.Title = truncate(source.Title, MaxTitleLength)
+		if source.Footer != nil {
+			source.Footer.Text = truncate(source.Footer.Text, MaxFooterTextLength)
+		}
+		if source.Author != nil {
+			source.Author.Name = truncate(source.Author.Name, MaxAuthorNameLength)
+		}
+	}
+
+	// Room is kept in every embed for the footer, which is only known to fit once the last embed is
+	// reached.
+	footer := 0
+	if source.Footer != nil {
+		footer = utf8.RuneCountInString(source.Footer.Text)
+	}
+
+	first := common.Embed{
+		Title:     source.Title,
+		Url:       source.Url,
+		Color:     source.Color,
+		Author:    source.Author,
+		Thumbnail: source.Thumbnail,
+	}
+	embeds := []common.Embed{first}
+
+	room := min(MaxDescriptionLength, MaxTotalLength-Length(first)-footer)
+	for i, chunk := range SplitText(source.Description, room) {
+		if i > 0 {
+			embeds = append(embeds, common.Embed{Color: source.Color})
+		}
+		embeds[len(embeds)-1].Description = chunk
+	}
+
+	if source.Fields != nil {
+		for _, field := range *source.Fields {
+
+			if b.truncate {
+				field.Name = truncate(field.Name, MaxFieldNameLength)
+			}
+
+			for _, part := range splitField(field) {
+
+				current := &embeds[len(embeds)-1]
+				size := utf8.RuneCountInString(part.Name) + utf8.RuneCountInString(part.Value)
+
+				full := current.Fields != nil && len(*current.Fields) >= MaxFields
+				if full || Length(*current) > 0 && Length(*current)+size+footer > MaxTotalLength {
+					embeds = append(embeds, common.Embed{Color: source.Color})
+					current = &embeds[len(embeds)-1]
+				}
+
+				if current.Fields == nil {
+					current.Fields = &[]common.EmbedField{}
+				}
+				*current.Fields = append(*current.Fields, part)
+			}
+		}
+	}
+
+	last := &embeds[len(embeds)-1]
+	last.Footer = source.Footer
+	last.Timestamp = source.Timestamp
+	last.Image = source.Image
+
+	var errs []error
+	for i, embed := range embeds {
+		errs = append(errs, within(fmt.Sprintf("embed %d", i+1), []error{Validate(embed)})...)
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	return embeds, nil
+}
+
+// Groups embeds into as few messages as possible, in order, keeping each message within
+// MaxEmbedsPerMessage and MaxTotalLength.
+func Messages(embeds []common.Embed) [][]common.Embed {
+
+	var messages [][]common.Embed
+	var current []common.Embed
+	total := 0
+
+	for _, embed := range embeds {
+
+		n := Length(embed)
+		if len(current) > 0 && (len(current) >= MaxEmbedsPerMessage || total+n > MaxTotalLength) {
+			messages = append(messages, current)
+			current, total = nil, 0
+		}
+
+		current = append(current, embed)
+		total += n
+	}
+
+	if len(current) > 0 {
+		messages = append(messages, current)
+	}
+
+	return messages
+}
+
+// Splits text into chunks of at most max characters. Chunks end at the last paragraph break, line
+// break or space that fits, and only break words that are longer than max.
+func SplitText(text string, max int) []string {
+
+	text = strings.TrimSpace(text)
+	if max < 1 {
+		max = 1
+	}
+
+	var chunks []string
+	for utf8.RuneCountInString(text) > max {
+
+		head := text[:byteOffset(text, max)]
+
+		end := len(head)
+		for _, separator := range []string{"\n\n", "\n", " "} {
+			if i := strings.LastIndex(head, separator); i > 0 {
+				end = i
+				break
+			}
+		}
+
+		if chunk := strings.TrimRightFunc(head[:end], isSpace); chunk != "" {
+			chunks = append(chunks, chunk)
+		}
+		text = strings.TrimLeftFunc(text[end:], isSpace)
+	}
+
+	if text != "" {
+		chunks = append(chunks, text)
+	}
+
+	return chunks
+}
+
+// Splits a field whose value is over MaxFieldValueLength into fields named ContinuedFieldName after
+// the first.
+func splitField(field common.EmbedField) []common.EmbedField {
+
+	chunks := SplitText(field.Value, MaxFieldValueLength)
+	if len(chunks) <= 1 {
+		return []common.EmbedField{field}
+	}
+
+	fields := make([]common.EmbedField, len(chunks))
+	for i, chunk := range chunks {
+		fields[i] = common.EmbedField{Name: ContinuedFieldName, Value: chunk, Inline: field.Inline}
+	}
+	fields[0].Name = field.Name
+
+	return fields
+}
+
+// Returns the byte offset of the character at index n of text.
+func byteOffset(text string, n int) int {
+
+	for offset := range text {
+		if n == 0 {
+			return offset
+		}
+		n--
+	}
+
+	return len(text)
+}
@@ -0,0 +1,103 @@
+package embeds
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSplitText(t *testing.T) {
+
+	tests := []struct {
+		name string
+		text string
+		max  int
+		want []string
+	}{
+		{
+			name: "fits",
+			text: "hello world",
+			max:  20,
+			want: []string{"hello world"},
+		},
+		{
+			name: "exactly max",
+			text: "hello",
+			max:  5,
+			want: []string{"hello"},
+		},
+		{
+			name: "empty",
+			text: "  \n ",
+			max:  5,
+			want: nil,
+		},
+		{
+			name: "breaks at space",
+			text: "one two three four",
+			max:  9,
+			want: []string{"one two", "three", "four"},
+		},
+		{
+			name: "prefers paragraph break",
+			text: "first line\nsecond\n\nthird",
+			max:  20,
+			want: []string{"first line\nsecond", "third"},
+		},
+		{
+			name: "prefers line break over space",
+			text: "aa bb\ncc dd ee",
+			max:  10,
+			want: []string{"aa bb", "cc dd ee"},
+		},
+		{
+			name: "breaks long words",
+			text: "abcdefghij",
+			max:  4,
+			want: []string{"abcd", "efgh", "ij"},
+		},
+		{
+			name: "counts characters, not bytes",
+			text: "ééé ééé",
+			max:  3,
+			want: []string{"ééé", "ééé"},
+		},
+		{
+			name: "zero max splits every character",
+			text: "abc",
+			max:  0,
+			want: []string{"a", "b", "c"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			got := SplitText(test.text, test.max)
+			if !slices.Equal(got, test.want) {
+				t.Fatalf("SplitText(%q, %d) = %q, want %q", test.text, test.max, got, test.want)
+			}
+
+			for _, chunk := range got {
+				if utf8.RuneCountInString(chunk) > max(test.max, 1) {
+					t.Errorf("chunk %q is over %d characters", chunk, test.max)
+				}
+			}
+		})
+	}
+}
+
+func TestSplitTextKeepsEveryWord(t *testing.T) {
+
+	text := strings.Repeat("lorem ipsum dolor sit amet\n", 200)
+
+	chunks := SplitText(text, MaxFieldValueLength)
+	if len(chunks) < 2 {
+		t.Fatalf("got %d chunks, want several", len(chunks))
+	}
+
+	if got, want := strings.Fields(strings.Join(chunks, " ")), strings.Fields(text); !slices.Equal(got, want) {
+		t.Errorf("chunks lost or changed words")
+	}
+}
@@ -1,15 +1,17 @@
 package gateway
 
+import "brandenly.com/go/packages/discord-bot/common"
+
 // External reference: https://discord.com/developers/docs/events/gateway-events#auto-moderation-action-execution
 type AutoModerationActionExecution struct {
-	GuildId              string               `json:"guild_id"`                          // ID of the guild in which action was executed
+	GuildId              common.Snowflake     `json:"guild_id"`                          // ID of the guild in which action was executed
 	Action               AutoModerationAction `json:"action"`                            // Action which was executed
-	RuleId               string               `json:"rule_id"`                           // ID of the rule which action belongs to
+	RuleId               common.Snowflake     `json:"rule_id"`                           // ID of the rule which action belongs to
 	RuleTriggerType      int                  `json:"rule_trigger_type"`                 // Trigger type of rule which was triggered
-	UserId               string               `json:"user_id"`                           // ID of the user which generated the content which triggered the rule
-	ChannelId            *string              `json:"channel_id,omitempty"`              // ID of the channel in which user content was posted
-	MessageId            *string              `json:"message_id,omitempty"`              // ID of any user message which content belongs to *
-	AlertSystemMessageId *string              `json:"alert_system_message_id,omitempty"` // ID of any system auto moderation messages posted as a result of this action **
+	UserId               common.Snowflake     `json:"user_id"`                           // ID of the user which generated the content which triggered the rule
+	ChannelId            *common.Snowflake    `json:"channel_id,omitempty"`              // ID of the channel in which user content was posted
+	MessageId            *common.Snowflake    `json:"message_id,omitempty"`              // ID of any user message which content belongs to *
+	AlertSystemMessageId *common.Snowflake    `json:"alert_system_message_id,omitempty"` // ID of any system auto moderation messages posted as a result of this action **
 	Content              string               `json:"content"`                           // User-generated text content
 	MatchedKeyword       *string              `json:"matched_keyword"`                   // Word or phrase configured in the rule that triggered the rule
 	MatchedContent       *string              `json:"matched_content"`                   // Substring in content that triggered the rule
@@ -1,6 +1,8 @@
 package gateway
 
+import "brandenly.com/go/packages/discord-bot/common"
+
 // External reference: https://discord.com/developers/docs/events/gateway-events#guild-integrations-update
 type GuildIntegrationsUpdate struct {
-	GuildId string `json:"guild_id"` // ID of the guild
+	GuildId common.Snowflake `json:"guild_id"` // ID of the guild
 }
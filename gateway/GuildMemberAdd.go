@@ -12,16 +12,16 @@ type GuildMemberAdd struct {
 	Nick                       *string                      `json:"nick,omitempty"`
 	Avatar                     *string                      `json:"avatar,omitempty"`
 	Banner                     *string                      `json:"banner,omitempty"`
-	Roles                      []string                     `json:"roles"`
+	Roles                      []common.Snowflake           `json:"roles"`
 	JoinedAt                   time.Time                    `json:"joined_at"`
 	PremiumSince               *time.Time                   `json:"premium_since,omitempty"`
 	Deaf                       bool                         `json:"deaf"`
 	Mute                       bool                         `json:"mute"`
 	Flags                      int                          `json:"flags"`
 	Pending                    *bool                        `json:"pending,omitempty"`
-	Permissions                *string                      `json:"permissions,omitempty"`
+	Permissions                *common.Permissions          `json:"permissions,omitempty"`
 	CommunicationDisabledUntil *time.Time                   `json:"communication_disabled_until,omitempty"`
 	AvatarDecorationData       *common.AvatarDecorationData `json:"avatar_decoration_data,omitempty"`
 
-	GuildId string `json:"guild_id"` // ID of the guild
+	GuildId common.Snowflake `json:"guild_id"` // ID of the guild
 }
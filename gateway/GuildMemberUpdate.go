@@ -8,8 +8,8 @@ import (
 
 // External reference: https://discord.com/developers/docs/events/gateway-events#guild-member-update
 type GuildMemberUpdate struct {
-	GuildId                    string                       `json:"guild_id"`                               // ID of the guild
-	Roles                      []string                     `json:"roles"`                                  // User role ids
+	GuildId                    common.Snowflake             `json:"guild_id"`                               // ID of the guild
+	Roles                      []common.Snowflake           `json:"roles"`                                  // User role ids
 	User                       common.User                  `json:"user"`                                   // User
 	Nick                       *string                      `json:"nick,omitempty"`                         // Nickname of the user in the guild
 	Avatar                     *string                      `json:"avatar,omitempty"`                       // Member's guild avatar hash
@@ -1,7 +1,9 @@
 package gateway
 
+import "brandenly.com/go/packages/discord-bot/common"
+
 // External reference: https://discord.com/developers/docs/events/gateway-events#guild-role-delete
 type GuildRoleDelete struct {
-	GuildId string `json:"guild_id"` // ID of the guild
-	RoleId  string `json:"role_id"`  // ID of the role
+	GuildId common.Snowflake `json:"guild_id"` // ID of the guild
+	RoleId  common.Snowflake `json:"role_id"`  // ID of the role
 }
@@ -4,6 +4,6 @@ import "brandenly.com/go/packages/discord-bot/common"
 
 // External reference: https://discord.com/developers/docs/events/gateway-events#guild-role-update
 type GuildRoleUpdate struct {
-	GuildId string      `json:"guild_id"` // ID of the guild
-	Role    common.Role `json:"role"`     // Role that was updated
+	GuildId common.Snowflake `json:"guild_id"` // ID of the guild
+	Role    common.Role      `json:"role"`     // Role that was updated
 }
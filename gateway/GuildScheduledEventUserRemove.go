@@ -1,8 +1,10 @@
 package gateway
 
+import "brandenly.com/go/packages/discord-bot/common"
+
 // External reference: https://discord.com/developers/docs/events/gateway-events#guild-scheduled-event-user-remove
 type GuildScheduledEventUserRemove struct {
-	GuildScheduledEventId string `json:"guild_scheduled_event_id"` // ID of the guild scheduled event
-	UserId                string `json:"user_id"`                  // ID of the user
-	GuildId               string `json:"guild_id"`                 // ID of the guild
+	GuildScheduledEventId common.Snowflake `json:"guild_scheduled_event_id"` // ID of the guild scheduled event
+	UserId                common.Snowflake `json:"user_id"`                  // ID of the user
+	GuildId               common.Snowflake `json:"guild_id"`                 // ID of the guild
 }
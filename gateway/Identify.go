@@ -10,7 +10,7 @@ type Identify struct {
 	LargeThreshold *uint8                 `json:"large_threshold,omitempty"` // Value between 50 and 250, total number of members where the gateway will stop sending offline members in the guild member list
 	Shard          *[2]int                `json:"shard,omitempty"`           // Used for [Guild Sharding](https://discord.com/developers/docs/events/gateway#sharding)
 	Presence       common.Presence        `json:"presence,omitempty"`        // Presence structure for initial presence information
-	Intents        int                    `json:"intents"`                   // Gateway Intents you wish to receive
+	Intents        Intents                `json:"intents"`                   // Gateway Intents you wish to receive
 }
 
 // External reference: https://discord.com/developers/docs/events/gateway-events#identify-identify-connection-properties
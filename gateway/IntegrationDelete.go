@@ -1,8 +1,10 @@
 package gateway
 
+import "brandenly.com/go/packages/discord-bot/common"
+
 // External reference: https://discord.com/developers/docs/events/gateway-events#integration-delete
 type IntegrationDelete struct {
-	Id            string  `json:"id"`                       // Integration ID
-	GuildId       string  `json:"guild_id"`                 // ID of the guild
-	ApplicationId *string `json:"application_id,omitempty"` // ID of the bot/OAuth2 application for this discord integration
+	Id            common.Snowflake  `json:"id"`                       // Integration ID
+	GuildId       common.Snowflake  `json:"guild_id"`                 // ID of the guild
+	ApplicationId *common.Snowflake `json:"application_id,omitempty"` // ID of the bot/OAuth2 application for this discord integration
 }
@@ -2,7 +2,7 @@ package gateway
 
 import (
 	"encoding/json"
-	"fmt"
+	"strconv"
 	"time"
 
 	"brandenly.com/go/packages/discord-bot/common"
@@ -23,69 +23,105 @@ var InteractionContextType map[string]uint8 = map[string]uint8{
 }
 
 type InteractionCreate struct {
-	Id                           string            `json:"id"`
-	ApplicationId                string            `json:"application_id"`
-	Type                         uint8             `json:"type"`
-	Data                         *json.RawMessage  `json:"data,omitempty"`
-	Guild                        *common.Guild     `json:"guild,omitempty"`
-	GuildId                      *string           `json:"guild_id,omitempty"`
-	Channel                      *common.Channel   `json:"channel,omitempty"`
-	ChannelId                    *string           `json:"channel_id,omitempty"`
-	Member                       *common.Member    `json:"member,omitempty"`
-	User                         *common.User      `json:"user,omitempty"`
-	Token                        string            `json:"token"`
-	Version                      uint              `json:"version"`
-	Message                      *common.Message   `json:"message,omitempty"`
-	AppPermissions               string            `json:"app_permissions"`
-	Locale                       *string           `json:"locale,omitempty"`
-	GuildLocale                  *string           `json:"guild_locale,omitempty"`
-	Entitlements                 []Entitlement     `json:"entitlments"`
-	AuthorizingIntegrationOwners map[string]string `json:"authorizing_integration_owners"`
-	Context                      *int              `json:"context,omitempty"`
+	Id                           common.Snowflake   `json:"id"`
+	ApplicationId                common.Snowflake   `json:"application_id"`
+	Type                         uint8              `json:"type"`
+	Data                         *json.RawMessage   `json:"data,omitempty"`
+	Guild                        *common.Guild      `json:"guild,omitempty"`
+	GuildId                      *common.Snowflake  `json:"guild_id,omitempty"`
+	Channel                      *common.Channel    `json:"channel,omitempty"`
+	ChannelId                    *common.Snowflake  `json:"channel_id,omitempty"`
+	Member                       *common.Member     `json:"member,omitempty"`
+	User                         *common.User       `json:"user,omitempty"`
+	Token                        string             `json:"token"`
+	Version                      uint               `json:"version"`
+	Message                      *common.Message    `json:"message,omitempty"`
+	AppPermissions               common.Permissions `json:"app_permissions"`
+	Locale                       *string            `json:"locale,omitempty"`
+	GuildLocale                  *string            `json:"guild_locale,omitempty"`
+	Entitlements                 []Entitlement      `json:"entitlments"`
+	AuthorizingIntegrationOwners map[string]string  `json:"authorizing_integration_owners"`
+	Context                      *int               `json:"context,omitempty"`
 }
 
 type InteractionApplicationCommandData struct {
-	Id       string                                   `json:"id"`
-	Name     string                                   `json:"name"`
-	Type     int                                      `json:"type"`
-	Resolved *ResolvedData                            `json:"resolved,omitempty"`
-	Options  *[]common.ApplicationCommandOptionChoice `json:"options,omitempty"`
-	GuildId  *string                                  `json:"guild_id,omitempty"`
-	TargetId *string                                  `json:"target_id,omitempty"`
+	Id       common.Snowflake         `json:"id"`
+	Name     string                   `json:"name"`
+	Type     int                      `json:"type"`
+	Resolved *ResolvedData            `json:"resolved,omitempty"`
+	Options  *[]InteractionDataOption `json:"options,omitempty"`
+	GuildId  *common.Snowflake        `json:"guild_id,omitempty"`
+	TargetId *common.Snowflake        `json:"target_id,omitempty"`
 }
 
+// An option of an invoked application command. Subcommands and subcommand groups carry the options
+// nested below them, other option types carry a value.
+// Reference: https://discord.com/developers/docs/interactions/receiving-and-responding#interaction-object-application-command-interaction-data-option-structure
+type InteractionDataOption struct {
+	Name    string                   `json:"name"`              // Name of the parameter
+	Type    int                      `json:"type"`              // Value of application command option type
+	Value   any                      `json:"value,omitempty"`   // Value of the option resulting from user input, typed by UnmarshalJSON
+	Options *[]InteractionDataOption `json:"options,omitempty"` // Present if this option is a group or subcommand
+	Focused *bool                    `json:"focused,omitempty"` // True if this option is the currently focused option for autocomplete
+}
+
+// Reference: https://discord.com/developers/docs/interactions/receiving-and-responding#interaction-object-message-component-data-structure
 type MessageComponentData struct {
-	CustomId      string           `json:"custom_id"`
-	ComponentType int              `json:"component_type"`
-	Values        *json.RawMessage `json:"values,omitempty"`
-	Resolved      *ResolvedData    `json:"resolved,omitempty"`
+	CustomId      string        `json:"custom_id"`          // Developer-defined identifier of the component
+	ComponentType int           `json:"component_type"`     // Type of the component
+	Values        []string      `json:"values,omitempty"`   // Values the user selected in a select menu; IDs for user, role, mentionable and channel selects
+	Resolved      *ResolvedData `json:"resolved,omitempty"` // Users, members, roles and channels selected in an auto-populated select menu
 }
 
 // Reference: https://discord.com/developers/docs/interactions/message-components#select-menu-object-select-option-structure
 type SelectOptionValue struct {
 }
 
+// Reference: https://discord.com/developers/docs/interactions/receiving-and-responding#interaction-object-modal-submit-data-structure
 type ModalSubmitData struct {
-	CustomId   string                    `json:"custom_id"`
-	Components []common.MessageComponent `json:"components"`
+	CustomId   string                    `json:"custom_id"`  // Developer-defined identifier of the modal
+	Components []common.MessageComponent `json:"components"` // Values submitted by the user, as action rows of text inputs
+}
+
+// Returns the submitted text input values by custom_id.
+func (d *ModalSubmitData) Values() map[string]string {
+
+	values := map[string]string{}
+
+	var walk func(components []common.MessageComponent)
+	walk = func(components []common.MessageComponent) {
+		for _, component := range components {
+
+			if component.CustomId != nil && component.Value != nil {
+				values[*component.CustomId] = *component.Value
+			}
+
+			if component.Components != nil {
+				walk(*component.Components)
+			}
+		}
+	}
+
+	walk(d.Components)
+	return values
 }
 
 // Reference: https://discord.com/developers/docs/interactions/receiving-and-responding#interaction-object-resolved-data-structure
 type ResolvedData struct {
-	Users       *map[string]common.User              `json:"users,omitempty"`
-	Members     *map[string]common.Member            `json:"members,omitempty"`
-	Roles       *map[string]common.Role              `json:"roles,omitempty"`
-	Channels    *map[string]common.Channel           `json:"channels,omitempty"`
-	Messages    *map[string]common.Message           `json:"messages,omitempty"`
-	Attachments *map[string]common.MessageAttachment `json:"attachments,omitempty"`
+	Users       *map[common.Snowflake]common.User              `json:"users,omitempty"`
+	Members     *map[common.Snowflake]common.Member            `json:"members,omitempty"`
+	Roles       *map[common.Snowflake]common.Role              `json:"roles,omitempty"`
+	Channels    *map[common.Snowflake]common.Channel           `json:"channels,omitempty"`
+	Messages    *map[common.Snowflake]common.Message           `json:"messages,omitempty"`
+	Attachments *map[common.Snowflake]common.MessageAttachment `json:"attachments,omitempty"`
 }
 
 type MessageInteraction struct {
-	Id     string         `json:"id"`
-	Type   uint8          `json:"type"`
-	Name   string         `json:"name"`
-	User   common.User    `json:"user"`
-	Member *common.Member `json:"member"`
+	Id     common.Snowflake `json:"id"`
+	Type   uint8            `json:"type"`
+	Name   string           `json:"name"`
+	User   common.User      `json:"user"`
+	Member *common.Member   `json:"member"`
 }
 
 var EntitlementTypes map[string]int = map[string]int{
@@ -101,16 +137,16 @@ var EntitlementTypes map[string]int = map[string]int{
 
 // Reference: https://discord.com/developers/docs/resources/entitlement#entitlement-object
 type Entitlement struct {
-	Id            string     `json:"id"`                 // ID of the entitlement
-	SkuId         string     `json:"sku_id"`             // ID of the SKU
-	ApplicationId string     `json:"application_id"`     // ID of the parent application
-	UserId        *string    `json:"user_id,omitempty"`  // ID of the user that is granted access to the entitlement's sku
-	Type          int        `json:"type"`               // Type of entitlement
-	Deleted       bool       `json:"deleted"`            // Entitlement was deleted
-	StartsAt      *time.Time `json:"starts_at"`          // Start date at which the entitlement is valid.
-	EndsAt        *time.Time `json:"ends_at"`            // Date at which the entitlement is no longer valid.
-	GuildId       *string    `json:"guild_id,omitempty"` // ID of the guild that is granted access to the entitlement's sku
-	Consumed      *bool      `json:"consumed,omitempty"` // For consumable items, whether or not the entitlement has been consumed
+	Id            common.Snowflake  `json:"id"`                 // ID of the entitlement
+	SkuId         common.Snowflake  `json:"sku_id"`             // ID of the SKU
+	ApplicationId common.Snowflake  `json:"application_id"`     // ID of the parent application
+	UserId        *common.Snowflake `json:"user_id,omitempty"`  // ID of the user that is granted access to the entitlement's sku
+	Type          int               `json:"type"`               // Type of entitlement
+	Deleted       bool              `json:"deleted"`            // Entitlement was deleted
+	StartsAt      *time.Time        `json:"starts_at"`          // Start date at which the entitlement is valid.
+	EndsAt        *time.Time        `json:"ends_at"`            // Date at which the entitlement is no longer valid.
+	GuildId       *common.Snowflake `json:"guild_id,omitempty"` // ID of the guild that is granted access to the entitlement's sku
+	Consumed      *bool             `json:"consumed,omitempty"` // For consumable items, whether or not the entitlement has been consumed
 }
 
 // Reference:
@@ -130,26 +166,13 @@ type CommandOptionChoice struct {
 	Type  int    `json:"type"`
 }
 
+// Returns the choice's value formatted as a string. INTEGER values decoded from JSON as float64 are
+// formatted without a fractional part.
 func (o *CommandOptionChoice) GetValueAsString() string {
 
-	var stringValue string = "unsupported type"
-
-	switch o.Type {
-	case 1: // SUB_COMMAND
-	case 2: // SUB_COMMAND_GROUP
-	case 3: // STRING
-		stringValue = fmt.Sprintf("%s", o.Value)
-	case 4: // INTEGER
-		stringValue = fmt.Sprintf("%.0f", o.Value)
-	case 5: // BOOL
-	case 6: // USER
-	case 7: // CHANNEL
-	case 8: // ROLE
-	case 9: // MENTIONABLE
-	case 10: // NUMBER
-		stringValue = fmt.Sprintf("%d", o.Value)
-	case 11: // ATTACHMENT
+	if number, ok := o.Value.(float64); ok && o.Type == common.IntegerApplicationCommandOptionType {
+		return strconv.FormatInt(int64(number), 10)
 	}
 
-	return stringValue
+	return formatOptionValue(o.Value)
 }
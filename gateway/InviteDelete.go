@@ -1,8 +1,10 @@
 package gateway
 
+import "brandenly.com/go/packages/discord-bot/common"
+
 // External reference: https://discord.com/developers/docs/events/gateway-events#invite-delete
 type InviteDelete struct {
-	ChannelId string `json:"channel_id"`         // Channel of the invite
-	GuildId   string `json:"guild_id,omitempty"` // Guild of the invite
-	Code      string `json:"code"`               // Unique invite code
+	ChannelId common.Snowflake `json:"channel_id"`         // Channel of the invite
+	GuildId   common.Snowflake `json:"guild_id,omitempty"` // Guild of the invite
+	Code      string           `json:"code"`               // Unique invite code
 }
@@ -1,8 +1,10 @@
 package gateway
 
+import "brandenly.com/go/packages/discord-bot/common"
+
 // External reference: https://discord.com/developers/docs/events/gateway-events#message-delete
 type MessageDelete struct {
-	Id        string  `json:"id"`                 // ID of the message
-	ChannelId string  `json:"channel_id"`         // ID of the channel
-	GuildId   *string `json:"guild_id,omitempty"` // ID of the guild
+	Id        common.Snowflake  `json:"id"`                 // ID of the message
+	ChannelId common.Snowflake  `json:"channel_id"`         // ID of the channel
+	GuildId   *common.Snowflake `json:"guild_id,omitempty"` // ID of the guild
 }
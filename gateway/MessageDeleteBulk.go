@@ -1,8 +1,10 @@
 package gateway
 
+import "brandenly.com/go/packages/discord-bot/common"
+
 // External reference: https://discord.com/developers/docs/events/gateway-events#message-delete-bulk
 type MessageDeleteBulk struct {
-	Ids       []string `json:"ids"`                // IDs of the messages
-	ChannelId string   `json:"channel_id"`         // ID of the channel
-	GuildId   *string  `json:"guild_id,omitempty"` // ID of the guild
+	Ids       []common.Snowflake `json:"ids"`                // IDs of the messages
+	ChannelId common.Snowflake   `json:"channel_id"`         // ID of the channel
+	GuildId   *common.Snowflake  `json:"guild_id,omitempty"` // ID of the guild
 }
@@ -8,8 +8,8 @@ import (
 
 // External reference: https://discord.com/developers/docs/events/gateway-events#message-create
 type MessageUpdate struct {
-	Id                  string                     `json:"id"`
-	ChannelId           string                     `json:"channel_id"`
+	Id                  common.Snowflake           `json:"id"`
+	ChannelId           common.Snowflake           `json:"channel_id"`
 	Author              common.User                `json:"author"`
 	Content             string                     `json:"content"`
 	Timestamp           time.Time                  `json:"timestamp"`
@@ -17,7 +17,7 @@ type MessageUpdate struct {
 	Tts                 bool                       `json:"tts"`
 	MentionEveryone     bool                       `json:"mention_everyone"`
 	Mentions            []common.User              `json:"mentions"`
-	MentionRoles        []common.Role              `json:"mention_roles"`
+	MentionRoles        []common.Snowflake         `json:"mention_roles"`
 	MentionChannels     *[]common.ChannelMention   `json:"mention_channels,omitempty"`
 	ReferencedMessage   *common.MessageReference   `json:"referenced_message,omitempty"`
 	Attachments         []common.MessageAttachment `json:"attachments"`
@@ -28,6 +28,6 @@ type MessageUpdate struct {
 	Components          *[]common.MessageComponent `json:"components,omitempty"`
 	Flags               *int                       `json:"flags,omitempty"`
 
-	GuildId *string        `json:"guild_id,omitempty"` // ID of the guild the message was sent in - unless it is an ephemeral message
-	Member  *common.Member `json:"member,omitempty"`   // Member properties for this message's author. Missing for ephemeral messages and messages from webhooks
+	GuildId *common.Snowflake `json:"guild_id,omitempty"` // ID of the guild the message was sent in - unless it is an ephemeral message
+	Member  *common.Member    `json:"member,omitempty"`   // Member properties for this message's author. Missing for ephemeral messages and messages from webhooks
 }
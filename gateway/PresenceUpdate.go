@@ -5,7 +5,7 @@ import "brandenly.com/go/packages/discord-bot/common"
 // External reference: https://discord.com/developers/docs/events/gateway-events#presence-update-presence-update-event-fields
 type PresenceUpdate struct {
 	User         common.User       `json:"user"`                    // User whose presence is being updated
-	GuildId      string            `json:"guild_id"`                // ID of the guild
+	GuildId      common.Snowflake  `json:"guild_id"`                // ID of the guild
 	Status       string            `json:"status"`                  // Either "idle", "dnd", "online", or "offline"
 	Activities   []common.Activity `json:"activities"`              // User's current activities
 	ClientStatus ClientStatus      `json:"client_status,omitempty"` // User's platform-dependent status
@@ -15,12 +15,12 @@ type Ready struct {
 
 // Reference: https://discord.com/developers/docs/resources/guild#unavailable-guild-object
 type UnavailableGuild struct {
-	Id          string `json:"id"`          // Guild id
-	Unavailable bool   `json:"unavailable"` //
+	Id          common.Snowflake `json:"id"`          // Guild id
+	Unavailable bool             `json:"unavailable"` //
 }
 
 // Reference: https://discord.com/developers/docs/resources/application#application-object-application-structure
 type PartialApplicationObject struct {
-	Id    string `json:"id"`    // ID of the app
-	Flags uint   `json:"flags"` // App's public flags
+	Id    common.Snowflake `json:"id"`    // ID of the app
+	Flags uint             `json:"flags"` // App's public flags
 }
@@ -0,0 +1,6 @@
+package gateway
+
+// External reference: https://discord.com/developers/docs/events/gateway-events#resumed
+type Resumed struct {
+	Trace []string `json:"_trace,omitempty"` // Debug information about the servers involved in the resume
+}
@@ -1,7 +1,9 @@
 package gateway
 
+import "brandenly.com/go/packages/discord-bot/common"
+
 // External reference: https://discord.com/developers/docs/events/gateway-events#webhooks-update
 type WebhooksUpdate struct {
-	GuildId   string `json:"guild_id"`   // ID of the guild
-	ChannelId string `json:"channel_id"` // ID of the channel
+	GuildId   common.Snowflake `json:"guild_id"`   // ID of the guild
+	ChannelId common.Snowflake `json:"channel_id"` // ID of the channel
 }
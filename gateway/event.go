@@ -3,6 +3,8 @@ package gateway
 import (
 	"encoding/json"
 	"fmt"
+	"reflect"
+	"strings"
 
 	"brandenly.com/go/packages/discord-bot/common"
 )
@@ -13,6 +15,8 @@ type Event struct {
 	D  any     `json:"d,omitempty"` // Event data
 	S  *int    `json:"s,omitempty"` // Sequence number of event used for resuming sessions and heartbeating
 	T  *string `json:"t,omitempty"` // Event name
+
+	Shard int `json:"-"` // Index of the shard that received the event
 }
 
 func (e *Event) UnmarshalJSON(data []byte) error {
@@ -97,11 +101,164 @@ func (e *Event) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// Returns the ID of the guild a dispatch event relates to, or zero if the event is not guild scoped.
+func (e *Event) GuildId() common.Snowflake {
+
+	if id := eventField(e.D, "GuildId"); id != 0 {
+		return id
+	}
+
+	// Guild events carry the guild ID as their own ID
+	if e.T != nil && (*e.T == "GUILD_CREATE" || *e.T == "GUILD_UPDATE" || *e.T == "GUILD_DELETE") {
+		return eventField(e.D, "Id")
+	}
+
+	return 0
+}
+
+// Returns the ID of the channel a dispatch event relates to, or zero if the event is not channel scoped.
+func (e *Event) ChannelId() common.Snowflake {
+
+	if id := eventField(e.D, "ChannelId"); id != 0 {
+		return id
+	}
+
+	// Channel events carry the channel ID as their own ID
+	if e.T != nil && (strings.HasPrefix(*e.T, "CHANNEL_") || strings.HasPrefix(*e.T, "THREAD_")) && *e.T != "THREAD_LIST_SYNC" {
+		return eventField(e.D, "Id")
+	}
+
+	return 0
+}
+
+// Returns the ID of the message a dispatch event relates to, such as a reaction's message, or an
+// zero if the event does not relate to a single message.
+func (e *Event) MessageId() common.Snowflake {
+
+	if id := eventField(e.D, "MessageId"); id != 0 {
+		return id
+	}
+
+	// Message events carry the message ID as their own ID
+	if e.T != nil && (*e.T == "MESSAGE_CREATE" || *e.T == "MESSAGE_UPDATE" || *e.T == "MESSAGE_DELETE" || *e.T == MessageEditedEvent || *e.T == MessageDeletedEvent) {
+		return eventField(e.D, "Id")
+	}
+
+	return 0
+}
+
+// Returns the ID of the user that triggered a dispatch event, or zero if there is none.
+func (e *Event) UserId() common.Snowflake {
+
+	if id := eventField(e.D, "UserId"); id != 0 {
+		return id
+	}
+
+	if user := e.User(); user != nil {
+		return user.Id
+	}
+
+	return 0
+}
+
+// Returns the user that triggered a dispatch event, such as a message author or interaction user,
+// or nil if the event data does not include one.
+func (e *Event) User() *common.User {
+
+	value := reflect.ValueOf(e.D)
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
+
+	for _, name := range []string{"Author", "User"} {
+		if user := userField(value.FieldByName(name)); user != nil {
+			return user
+		}
+	}
+
+	// Guild interactions and member events only carry the user inside the member
+	if member := value.FieldByName("Member"); member.IsValid() {
+		for member.Kind() == reflect.Pointer {
+			if member.IsNil() {
+				return nil
+			}
+			member = member.Elem()
+		}
+		if member.Kind() == reflect.Struct {
+			return userField(member.FieldByName("User"))
+		}
+	}
+
+	return nil
+}
+
+// Returns the user held by a User or *User field, or nil if the field is missing or unset.
+func userField(field reflect.Value) *common.User {
+
+	if !field.IsValid() {
+		return nil
+	}
+
+	switch user := field.Interface().(type) {
+	case common.User:
+		return &user
+	case *common.User:
+		return user
+	}
+
+	return nil
+}
+
+// Reads the named ID field from an event data struct, returning zero when it is missing or unset.
+func eventField(data any, name string) common.Snowflake {
+
+	value := reflect.ValueOf(data)
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return 0
+		}
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Struct {
+		return 0
+	}
+
+	field := value.FieldByName(name)
+	if !field.IsValid() {
+		return 0
+	}
+
+	for field.Kind() == reflect.Pointer {
+		if field.IsNil() {
+			return 0
+		}
+		field = field.Elem()
+	}
+
+	switch id := field.Interface().(type) {
+	case common.Snowflake:
+		return id
+	case string:
+		parsed, _ := common.ParseSnowflake(id)
+		return parsed
+	}
+
+	return 0
+}
+
 var EventTypeStructs map[string]func() any = map[string]func() any{
-	"HELLO": func() any { return &Hello{} },
-	"READY": func() any { return &Ready{} },
-	// "RESUMED": ,
-	// "RECONNECT": ,
+	"HELLO":   func() any { return &Hello{} },
+	"READY":   func() any { return &Ready{} },
+	"RESUMED": func() any { return &Resumed{} },
+	// RECONNECT is sent as opcode 7 rather than as a dispatch; see ShardDisconnectedEvent
 	"APPLICATION_COMMAND_PERMISSIONS_UPDATE": func() any { return &common.ApplicationCommandPermissions{} },
 	"AUTO_MODERATION_RULE_CREATE":            func() any { return &common.AutoModerationRule{} },
 	"AUTO_MODERATION_RULE_UPDATE":            func() any { return &common.AutoModerationRule{} },
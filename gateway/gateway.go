@@ -18,12 +18,17 @@ import (
 	"github.com/gorilla/websocket"
 )
 
+// Default buffer size of a connection's raw frame queue
+const DefaultQueueSize = 256
+
 type Connection struct {
 	ShardIndex int
 	Outgoing   chan Event
 	Incoming   chan Event
 	BotToken   *string
 	Logger     *log.Logger
+	Recorder   *Recorder // Optional; records every raw frame received by the connection
+	QueueSize  int       // Buffer size of the raw frame queue, defaults to DefaultQueueSize
 	gatewayUrl *url.URL
 	conn       *websocket.Conn
 	ctx        context.Context
@@ -33,6 +38,13 @@ type Connection struct {
 	heartbeatInterval uint
 	session           session
 	parentCtx         context.Context
+	frames            chan frame // Frames waiting to be processed, in the order they were read
+}
+
+// An entry in a connection's frame queue: either a raw gateway frame or a synthetic lifecycle event.
+type frame struct {
+	data  []byte
+	event *Event
 }
 
 type session struct {
@@ -40,9 +52,11 @@ type session struct {
 	ResumeGatewayUrl *url.URL
 	LastSequence     *int
 	Wg               sync.WaitGroup
+	Hello            chan Event
 	InvalidSession   chan any
 	SuccessfulResume chan any
 	NewSession       chan any
+	seqMu            sync.Mutex
 }
 
 // External reference: https://discord.com/developers/docs/events/gateway#connecting
@@ -74,18 +88,29 @@ func (c *Connection) Connect(ctx context.Context, wg *sync.WaitGroup, config *Ga
 
 	c.gatewayUrl = gatewayUrl
 
+	// Create hello channel
+	c.session.Hello = make(chan Event, 1)
+
 	// Create invalid session channel
-	c.session.InvalidSession = make(chan any)
+	c.session.InvalidSession = make(chan any, 1)
 
 	// Create invalid session channel
 	c.session.NewSession = make(chan any)
 
 	// Create successful resume channel
-	c.session.SuccessfulResume = make(chan any)
+	c.session.SuccessfulResume = make(chan any, 1)
 
 	// Cache outer context
 	c.parentCtx = ctx
 
+	// Create the frame queue; a single processor drains it for the lifetime of the connection so
+	// frames are handled in the order they were received, across resumes.
+	if c.QueueSize <= 0 {
+		c.QueueSize = DefaultQueueSize
+	}
+	c.frames = make(chan frame, c.QueueSize)
+	go c.process()
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -102,6 +127,8 @@ func (c *Connection) Connect(ctx context.Context, wg *sync.WaitGroup, config *Ga
 			// Reset context
 			c.ctx, c.cancel = context.WithCancel(c.parentCtx)
 
+			c.emit(ShardConnectingEvent, &ShardConnecting{Shard: c.ShardIndex})
+
 			// Store websocket connection object
 			conn, _, err := websocket.DefaultDialer.Dial(c.gatewayUrl.String(), nil)
 			if err != nil {
@@ -118,7 +145,7 @@ func (c *Connection) Connect(ctx context.Context, wg *sync.WaitGroup, config *Ga
 
 			// Receive Hello event & Identify
 			select {
-			case hello := <-c.Incoming:
+			case hello := <-c.session.Hello:
 
 				helloData, ok := hello.D.(Hello)
 				if !ok {
@@ -190,6 +217,8 @@ func (c *Connection) Resume() error {
 		return fmt.Errorf("disconnect failed: %w", err)
 	}
 
+	c.emit(ShardConnectingEvent, &ShardConnecting{Shard: c.ShardIndex, Resuming: true})
+
 	// Create new gateway connection
 	conn, _, err := websocket.DefaultDialer.Dial(c.session.ResumeGatewayUrl.String(), nil)
 	if err != nil {
@@ -207,12 +236,17 @@ func (c *Connection) Resume() error {
 	go c.sendHeartbeats(c.heartbeatInterval) // Begin sending heartbeats
 
 	// Send Resume Event
+	var seq int
+	if lastSequence := c.sequence(); lastSequence != nil {
+		seq = *lastSequence
+	}
+
 	var resume Event = Event{
 		Op: 6,
 		D: Resume{
 			Token:     *c.BotToken,
 			SessionId: *c.session.Id,
-			Seq:       *c.session.LastSequence,
+			Seq:       seq,
 		},
 	}
 	c.Outgoing <- resume
@@ -308,12 +342,20 @@ func (c *Connection) receive() {
 
 			if err != nil {
 
+				disconnected := &ShardDisconnected{Shard: c.ShardIndex, Reason: err.Error(), Resumable: true}
+
 				if websocketCloseErr, ok := err.(*websocket.CloseError); ok {
 
 					// Handle clean close
 
 					c.Logger.Printf("Connection closed with close code: %+v", websocketCloseErr)
 
+					disconnected.CloseCode = websocketCloseErr.Code
+					disconnected.Reason = CloseCodeDescription(websocketCloseErr.Code, websocketCloseErr.Text)
+					if resumable, ok := closeIsResumable[websocketCloseErr.Code]; ok {
+						disconnected.Resumable = resumable
+					}
+
 				} else {
 
 					// Handle abrupt close
@@ -322,6 +364,8 @@ func (c *Connection) receive() {
 
 				}
 
+				c.emit(ShardDisconnectedEvent, disconnected)
+
 				if c.active { // Attempt to resume
 
 					go func() {
@@ -337,9 +381,21 @@ func (c *Connection) receive() {
 
 			}
 
-			// Process incoming message
+			// Record raw frame
+			if c.Recorder != nil && len(msg) > 0 {
+				if err := c.Recorder.Record(c.ShardIndex, msg); err != nil {
+					c.Logger.Printf("Failed to record incoming frame: %s", err.Error())
+				}
+			}
+
+			// Queue incoming message, blocking while the queue is full so a slow consumer
+			// applies backpressure to the socket rather than reordering frames
 			if len(msg) > 0 {
-				go c.processIncoming(msg)
+				select {
+				case c.frames <- frame{data: msg}:
+				case <-c.ctx.Done():
+					return // Stop receiving
+				}
 			}
 
 		}
@@ -347,6 +403,42 @@ func (c *Connection) receive() {
 
 }
 
+// Drains the frame queue one frame at a time, preserving the order frames were received in.
+func (c *Connection) process() {
+	for {
+		select {
+		case <-c.parentCtx.Done():
+			return // Stop processing
+		case f := <-c.frames:
+
+			if f.event != nil {
+				c.forward(*f.event) // Synthetic events need no processing
+				continue
+			}
+
+			c.processIncoming(f.data)
+		}
+	}
+}
+
+// Queues a synthetic lifecycle event behind any frames already received.
+func (c *Connection) emit(eventType string, data any) {
+	event := NewSyntheticEvent(eventType, c.ShardIndex, data)
+
+	select {
+	case c.frames <- frame{event: &event}:
+	case <-c.parentCtx.Done():
+	}
+}
+
+// Passes a processed event on to the incoming channel.
+func (c *Connection) forward(event Event) {
+	select {
+	case c.Incoming <- event:
+	case <-c.parentCtx.Done():
+	}
+}
+
 func (c *Connection) processIncoming(message []byte) {
 
 	var E Event
@@ -356,14 +448,21 @@ func (c *Connection) processIncoming(message []byte) {
 		return
 	}
 
+	E.Shard = c.ShardIndex
+
 	// Update sequence number
 	if E.S != nil {
-		c.session.LastSequence = E.S
+		c.setSequence(*E.S)
+	}
+
+	// Handle hello
+	if E.Op == 10 {
+		signal(c.session.Hello, E)
 	}
 
 	// Handle successful resume
 	if E.Op == 9 {
-		c.session.InvalidSession <- E
+		signal(c.session.InvalidSession, any(E))
 	}
 
 	// Handle reconnect events
@@ -406,13 +505,47 @@ func (c *Connection) processIncoming(message []byte) {
 		}
 
 		if *E.T == "RESUMED" {
-			c.session.SuccessfulResume <- E
+			signal(c.session.SuccessfulResume, any(E))
 		}
 
 	}
 
 	// Forward for additional processing
-	c.Incoming <- E
+	c.forward(E)
+}
+
+// Returns the last sequence number received.
+func (c *Connection) sequence() *int {
+	c.session.seqMu.Lock()
+	defer c.session.seqMu.Unlock()
+
+	return c.session.LastSequence
+}
+
+// Records a received sequence number; the stored sequence never moves backwards.
+func (c *Connection) setSequence(seq int) {
+	c.session.seqMu.Lock()
+	defer c.session.seqMu.Unlock()
+
+	if c.session.LastSequence == nil || seq > *c.session.LastSequence {
+		c.session.LastSequence = &seq
+	}
+}
+
+// Performs a non-blocking send on a session channel, replacing any signal nobody has consumed yet.
+func signal[T any](ch chan T, value T) {
+	for {
+		select {
+		case ch <- value:
+			return
+		default:
+		}
+
+		select {
+		case <-ch: // Discard stale signal
+		default:
+		}
+	}
 }
 
 // Handles the sending of heartbeat events at the specified interval
@@ -440,7 +573,7 @@ func (c *Connection) sendHeartbeats(interval uint) {
 			// send heartbeat here
 			var Pulse Event = Event{
 				Op: 1,
-				D:  c.session.LastSequence,
+				D:  c.sequence(),
 			}
 
 			c.Outgoing <- Pulse
@@ -518,6 +651,38 @@ var closeIsResumable map[int]bool = map[int]bool{
 	4014: false, // Disallowed intent(s)
 }
 
+// Describes a gateway close code, preferring the reason sent with the close frame when there is one.
+func CloseCodeDescription(code int, text string) string {
+
+	if text != "" {
+		return text
+	}
+
+	if description, ok := closeCodeDescriptions[code]; ok {
+		return description
+	}
+
+	return fmt.Sprintf("closed with code %d", code)
+}
+
+// External reference: https://discord.com/developers/docs/topics/opcodes-and-status-codes#gateway-gateway-close-event-codes
+var closeCodeDescriptions map[int]string = map[int]string{
+	4000: "Unknown error",
+	4001: "Unknown opcode",
+	4002: "Decode error",
+	4003: "Not authenticated",
+	4004: "Authentication failed",
+	4005: "Already authenticated",
+	4007: "Invalid seq",
+	4008: "Rate limited",
+	4009: "Session timed out",
+	4010: "Invalid shard",
+	4011: "Sharding required",
+	4012: "Invalid API version",
+	4013: "Invalid intent(s)",
+	4014: "Disallowed intent(s): a privileged intent was requested that is not enabled for the application in the Developer Portal",
+}
+
 // GetGID returns the current goroutine's ID (for debugging purposes only)
 func GetGID() uint64 {
 	b := make([]byte, 64)
@@ -0,0 +1,196 @@
+package gateway
+
+import (
+	"fmt"
+	"math/bits"
+	"strings"
+)
+
+// A set of gateway intents, selecting which events a connection receives.
+// External reference: https://discord.com/developers/docs/events/gateway#gateway-intents
+type Intents uint64
+
+const (
+	IntentGuilds                      Intents = 1 << 0
+	IntentGuildMembers                Intents = 1 << 1 // Privileged
+	IntentGuildBans                   Intents = 1 << 2 // Also known as GUILD_MODERATION
+	IntentGuildEmojisAndStickers      Intents = 1 << 3 // Also known as GUILD_EXPRESSIONS
+	IntentGuildIntegrations           Intents = 1 << 4
+	IntentGuildWebhooks               Intents = 1 << 5
+	IntentGuildInvites                Intents = 1 << 6
+	IntentGuildVoiceStates            Intents = 1 << 7
+	IntentGuildPresences              Intents = 1 << 8 // Privileged
+	IntentGuildMessages               Intents = 1 << 9
+	IntentGuildMessageReactions       Intents = 1 << 10
+	IntentGuildMessageTyping          Intents = 1 << 11
+	IntentDirectMessages              Intents = 1 << 12
+	IntentDirectMessageReactions      Intents = 1 << 13
+	IntentDirectMessageTyping         Intents = 1 << 14
+	IntentMessageContent              Intents = 1 << 15 // Privileged
+	IntentGuildScheduledEvents        Intents = 1 << 16
+	IntentAutoModerationConfiguration Intents = 1 << 20
+	IntentAutoModerationExecution     Intents = 1 << 21
+	IntentGuildMessagePolls           Intents = 1 << 24
+	IntentDirectMessagePolls          Intents = 1 << 25
+)
+
+// Intents that must be enabled for the application in the Developer Portal before they can be
+// requested; requesting them otherwise closes the connection with 4014.
+const PrivilegedIntents Intents = IntentGuildMembers | IntentGuildPresences | IntentMessageContent
+
+var IntentNames map[string]Intents = map[string]Intents{
+	"GUILDS":                        IntentGuilds,
+	"GUILD_MEMBERS":                 IntentGuildMembers,
+	"GUILD_BANS":                    IntentGuildBans,
+	"GUILD_EMOJIS_AND_STICKERS":     IntentGuildEmojisAndStickers,
+	"GUILD_INTEGRATIONS":            IntentGuildIntegrations,
+	"GUILD_WEBHOOKS":                IntentGuildWebhooks,
+	"GUILD_INVITES":                 IntentGuildInvites,
+	"GUILD_VOICE_STATES":            IntentGuildVoiceStates,
+	"GUILD_PRESENCES":               IntentGuildPresences,
+	"GUILD_MESSAGES":                IntentGuildMessages,
+	"GUILD_MESSAGE_REACTIONS":       IntentGuildMessageReactions,
+	"GUILD_MESSAGE_TYPING":          IntentGuildMessageTyping,
+	"DIRECT_MESSAGES":               IntentDirectMessages,
+	"DIRECT_MESSAGE_REACTIONS":      IntentDirectMessageReactions,
+	"DIRECT_MESSAGE_TYPING":         IntentDirectMessageTyping,
+	"MESSAGE_CONTENT":               IntentMessageContent,
+	"GUILD_SCHEDULED_EVENTS":        IntentGuildScheduledEvents,
+	"AUTO_MODERATION_CONFIGURATION": IntentAutoModerationConfiguration,
+	"AUTO_MODERATION_EXECUTION":     IntentAutoModerationExecution,
+	"GUILD_MESSAGE_POLLS":           IntentGuildMessagePolls,
+	"DIRECT_MESSAGE_POLLS":          IntentDirectMessagePolls,
+}
+
+// The intents under which each dispatch event is delivered; an event is received when any of its
+// intents is requested. Events not listed are always delivered.
+// External reference: https://discord.com/developers/docs/events/gateway#list-of-intents
+var EventIntents map[string]Intents = map[string]Intents{
+	"GUILD_CREATE":                      IntentGuilds,
+	"GUILD_UPDATE":                      IntentGuilds,
+	"GUILD_DELETE":                      IntentGuilds,
+	"GUILD_ROLE_CREATE":                 IntentGuilds,
+	"GUILD_ROLE_UPDATE":                 IntentGuilds,
+	"GUILD_ROLE_DELETE":                 IntentGuilds,
+	"CHANNEL_CREATE":                    IntentGuilds,
+	"CHANNEL_UPDATE":                    IntentGuilds,
+	"CHANNEL_DELETE":                    IntentGuilds,
+	"CHANNEL_PINS_UPDATE":               IntentGuilds | IntentDirectMessages,
+	"THREAD_CREATE":                     IntentGuilds,
+	"THREAD_UPDATE":                     IntentGuilds,
+	"THREAD_DELETE":                     IntentGuilds,
+	"THREAD_LIST_SYNC":                  IntentGuilds,
+	"THREAD_MEMBER_UPDATE":              IntentGuilds,
+	"THREAD_MEMBERS_UPDATE":             IntentGuilds | IntentGuildMembers,
+	"STAGE_INSTANCE_CREATE":             IntentGuilds,
+	"STAGE_INSTANCE_UPDATE":             IntentGuilds,
+	"STAGE_INSTANCE_DELETE":             IntentGuilds,
+	"GUILD_MEMBER_ADD":                  IntentGuildMembers,
+	"GUILD_MEMBER_UPDATE":               IntentGuildMembers,
+	"GUILD_MEMBER_REMOVE":               IntentGuildMembers,
+	"GUILD_AUDIT_LOG_ENTRY_CREATE":      IntentGuildBans,
+	"GUILD_BAN_ADD":                     IntentGuildBans,
+	"GUILD_BAN_REMOVE":                  IntentGuildBans,
+	"GUILD_EMOJIS_UPDATE":               IntentGuildEmojisAndStickers,
+	"GUILD_STICKERS_UPDATE":             IntentGuildEmojisAndStickers,
+	"GUILD_SOUNDBOARD_SOUND_CREATE":     IntentGuildEmojisAndStickers,
+	"GUILD_SOUNDBOARD_SOUND_UPDATE":     IntentGuildEmojisAndStickers,
+	"GUILD_SOUNDBOARD_SOUND_DELETE":     IntentGuildEmojisAndStickers,
+	"GUILD_SOUNDBOARD_SOUNDS_UPDATE":    IntentGuildEmojisAndStickers,
+	"GUILD_INTEGRATIONS_UPDATE":         IntentGuildIntegrations,
+	"INTEGRATION_CREATE":                IntentGuildIntegrations,
+	"INTEGRATION_UPDATE":                IntentGuildIntegrations,
+	"INTEGRATION_DELETE":                IntentGuildIntegrations,
+	"WEBHOOKS_UPDATE":                   IntentGuildWebhooks,
+	"INVITE_CREATE":                     IntentGuildInvites,
+	"INVITE_DELETE":                     IntentGuildInvites,
+	"VOICE_CHANNEL_EFFECT_SEND":         IntentGuildVoiceStates,
+	"VOICE_STATE_UPDATE":                IntentGuildVoiceStates,
+	"PRESENCE_UPDATE":                   IntentGuildPresences,
+	"MESSAGE_CREATE":                    IntentGuildMessages | IntentDirectMessages,
+	"MESSAGE_UPDATE":                    IntentGuildMessages | IntentDirectMessages,
+	"MESSAGE_DELETE":                    IntentGuildMessages | IntentDirectMessages,
+	"MESSAGE_DELETE_BULK":               IntentGuildMessages,
+	"MESSAGE_REACTION_ADD":              IntentGuildMessageReactions | IntentDirectMessageReactions,
+	"MESSAGE_REACTION_REMOVE":           IntentGuildMessageReactions | IntentDirectMessageReactions,
+	"MESSAGE_REACTION_REMOVE_ALL":       IntentGuildMessageReactions | IntentDirectMessageReactions,
+	"MESSAGE_REACTION_REMOVE_EMOJI":     IntentGuildMessageReactions | IntentDirectMessageReactions,
+	"TYPING_START":                      IntentGuildMessageTyping | IntentDirectMessageTyping,
+	"GUILD_SCHEDULED_EVENT_CREATE":      IntentGuildScheduledEvents,
+	"GUILD_SCHEDULED_EVENT_UPDATE":      IntentGuildScheduledEvents,
+	"GUILD_SCHEDULED_EVENT_DELETE":      IntentGuildScheduledEvents,
+	"GUILD_SCHEDULED_EVENT_USER_ADD":    IntentGuildScheduledEvents,
+	"GUILD_SCHEDULED_EVENT_USER_REMOVE": IntentGuildScheduledEvents,
+	"AUTO_MODERATION_RULE_CREATE":       IntentAutoModerationConfiguration,
+	"AUTO_MODERATION_RULE_UPDATE":       IntentAutoModerationConfiguration,
+	"AUTO_MODERATION_RULE_DELETE":       IntentAutoModerationConfiguration,
+	"AUTO_MODERATION_ACTION_EXECUTION":  IntentAutoModerationExecution,
+	"MESSAGE_POLL_VOTE_ADD":             IntentGuildMessagePolls | IntentDirectMessagePolls,
+	"MESSAGE_POLL_VOTE_REMOVE":          IntentGuildMessagePolls | IntentDirectMessagePolls,
+
+	// Synthetic events derived from the events above
+	GuildAvailableEvent:      IntentGuilds,
+	GuildJoinedEvent:         IntentGuilds,
+	GuildUnavailableEvent:    IntentGuilds,
+	GuildRemovedEvent:        IntentGuilds,
+	MessageEditedEvent:       IntentGuildMessages | IntentDirectMessages,
+	MessageDeletedEvent:      IntentGuildMessages | IntentDirectMessages,
+	MessagesBulkDeletedEvent: IntentGuildMessages,
+}
+
+// Reports whether every intent in i is set.
+func (intents Intents) Has(i Intents) bool {
+	return intents&i == i
+}
+
+// Reports whether any intent in i is set.
+func (intents Intents) HasAny(i Intents) bool {
+	return intents&i != 0
+}
+
+// Returns the set with the given intents added.
+func (intents Intents) Add(i ...Intents) Intents {
+	for _, intent := range i {
+		intents |= intent
+	}
+	return intents
+}
+
+// Returns the set with the given intents removed.
+func (intents Intents) Remove(i ...Intents) Intents {
+	for _, intent := range i {
+		intents &^= intent
+	}
+	return intents
+}
+
+// Returns the privileged intents in the set.
+func (intents Intents) Privileged() Intents {
+	return intents & PrivilegedIntents
+}
+
+// Returns the names of the set intents in bit order, joined by "|". Unknown bits are listed by their
+// bit number.
+func (intents Intents) String() string {
+
+	if intents == 0 {
+		return "NONE"
+	}
+
+	names := make(map[Intents]string, len(IntentNames))
+	for name, intent := range IntentNames {
+		names[intent] = name
+	}
+
+	var parts []string
+	for remaining := uint64(intents); remaining != 0; remaining &= remaining - 1 {
+		bit := Intents(1) << bits.TrailingZeros64(remaining)
+		if name, ok := names[bit]; ok {
+			parts = append(parts, name)
+		} else {
+			parts = append(parts, fmt.Sprintf("1<<%d", bits.TrailingZeros64(remaining)))
+		}
+	}
+
+	return strings.Join(parts, "|")
+}
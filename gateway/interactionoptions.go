@@ -0,0 +1,318 @@
+package gateway
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"brandenly.com/go/packages/discord-bot/common"
+)
+
+//// Option Values
+
+// Decodes the option's value into the Go type of its option type: string for STRING, int64 for
+// INTEGER, bool for BOOLEAN, float64 for NUMBER and common.Snowflake for USER, CHANNEL, ROLE,
+// MENTIONABLE and ATTACHMENT. The partial input of a focused autocomplete option is kept as a string.
+func (o *InteractionDataOption) UnmarshalJSON(data []byte) error {
+
+	type option InteractionDataOption
+
+	var raw struct {
+		option
+		Value json.RawMessage `json:"value,omitempty"`
+	}
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*o = InteractionDataOption(raw.option)
+
+	if len(raw.Value) == 0 || string(raw.Value) == "null" {
+		return nil
+	}
+
+	value, err := decodeOptionValue(o.Type, raw.Value)
+	if err != nil {
+
+		var partial string
+		if o.Focused == nil || !*o.Focused || json.Unmarshal(raw.Value, &partial) != nil {
+			return fmt.Errorf("option %q: %w", o.Name, err)
+		}
+
+		value = partial
+	}
+
+	o.Value = value
+	return nil
+}
+
+func decodeOptionValue(optionType int, data json.RawMessage) (any, error) {
+
+	var err error
+
+	switch optionType {
+
+	case common.StringApplicationCommandOptionType:
+		var value string
+		err = json.Unmarshal(data, &value)
+		return value, err
+
+	case common.IntegerApplicationCommandOptionType:
+		var value int64
+		err = json.Unmarshal(data, &value)
+		return value, err
+
+	case common.BooleanApplicationCommandOptionType:
+		var value bool
+		err = json.Unmarshal(data, &value)
+		return value, err
+
+	case common.NumberApplicationCommandOptionType:
+		var value float64
+		err = json.Unmarshal(data, &value)
+		return value, err
+
+	case common.UserApplicationCommandOptionType,
+		common.ChannelApplicationCommandOptionType,
+		common.RoleApplicationCommandOptionType,
+		common.MentionableApplicationCommandOptionType,
+		common.AttachmentApplicationCommandOptionType:
+		var value common.Snowflake
+		err = json.Unmarshal(data, &value)
+		return value, err
+	}
+
+	var value any
+	err = json.Unmarshal(data, &value)
+	return value, err
+}
+
+// Returns the value of a STRING option, or the partial input of a focused option.
+func (o *InteractionDataOption) StringValue() (string, bool) {
+	value, ok := o.Value.(string)
+	return value, ok
+}
+
+// Returns the value of an INTEGER option.
+func (o *InteractionDataOption) IntValue() (int64, bool) {
+	value, ok := o.Value.(int64)
+	return value, ok
+}
+
+// Returns the value of a NUMBER option. INTEGER values are converted.
+func (o *InteractionDataOption) NumberValue() (float64, bool) {
+
+	switch value := o.Value.(type) {
+	case float64:
+		return value, true
+	case int64:
+		return float64(value), true
+	}
+
+	return 0, false
+}
+
+// Returns the value of a BOOLEAN option.
+func (o *InteractionDataOption) BoolValue() (bool, bool) {
+	value, ok := o.Value.(bool)
+	return value, ok
+}
+
+// Returns the ID held by a USER, CHANNEL, ROLE, MENTIONABLE or ATTACHMENT option.
+func (o *InteractionDataOption) SnowflakeValue() (common.Snowflake, bool) {
+	value, ok := o.Value.(common.Snowflake)
+	return value, ok
+}
+
+// Returns the options nested below a subcommand or subcommand group.
+func (o *InteractionDataOption) NestedOptions() []InteractionDataOption {
+
+	if o.Options == nil {
+		return nil
+	}
+
+	return *o.Options
+}
+
+// Reports whether the option is a subcommand or subcommand group.
+func (o *InteractionDataOption) IsSubcommand() bool {
+	return o.Type == common.SubCommandApplicationCommandOptionType || o.Type == common.SubCommandGroupApplicationCommandOptionType
+}
+
+// Returns the option's value formatted as a string; subcommands have no value and return "".
+func (o *InteractionDataOption) GetValueAsString() string {
+	return formatOptionValue(o.Value)
+}
+
+func formatOptionValue(value any) string {
+
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case int64:
+		return strconv.FormatInt(value, 10)
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(value)
+	case common.Snowflake:
+		return value.String()
+	}
+
+	return fmt.Sprint(value)
+}
+
+//// Invoked Subcommands
+
+// Follows subcommand group and subcommand options down to the invoked command, returning the names
+// of the subcommands on the way and the options given to the invoked command.
+func (d *InteractionApplicationCommandData) Subcommand() ([]string, []InteractionDataOption) {
+
+	var path []string
+
+	var options []InteractionDataOption
+	if d.Options != nil {
+		options = *d.Options
+	}
+
+	for len(options) > 0 && options[0].IsSubcommand() {
+		path = append(path, options[0].Name)
+		options = options[0].NestedOptions()
+	}
+
+	return path, options
+}
+
+// Returns the option given to the invoked command with the given name.
+func (d *InteractionApplicationCommandData) Option(name string) (InteractionDataOption, bool) {
+
+	_, options := d.Subcommand()
+
+	for _, option := range options {
+		if option.Name == name {
+			return option, true
+		}
+	}
+
+	return InteractionDataOption{}, false
+}
+
+// Returns the option the user is typing in, for autocomplete interactions.
+func (d *InteractionApplicationCommandData) Focused() (InteractionDataOption, bool) {
+
+	_, options := d.Subcommand()
+
+	for _, option := range options {
+		if option.Focused != nil && *option.Focused {
+			return option, true
+		}
+	}
+
+	return InteractionDataOption{}, false
+}
+
+//// Resolved Objects
+
+// Returns a resolved user.
+func (r *ResolvedData) User(id common.Snowflake) (common.User, bool) {
+	return lookupResolved(r, func(r *ResolvedData) *map[common.Snowflake]common.User { return r.Users }, id)
+}
+
+// Returns a resolved guild member. Resolved members don't include their user, so it is filled in
+// from the resolved users.
+func (r *ResolvedData) Member(id common.Snowflake) (common.Member, bool) {
+
+	member, ok := lookupResolved(r, func(r *ResolvedData) *map[common.Snowflake]common.Member { return r.Members }, id)
+	if ok && member.User == nil {
+		if user, found := r.User(id); found {
+			member.User = &user
+		}
+	}
+
+	return member, ok
+}
+
+// Returns a resolved role.
+func (r *ResolvedData) Role(id common.Snowflake) (common.Role, bool) {
+	return lookupResolved(r, func(r *ResolvedData) *map[common.Snowflake]common.Role { return r.Roles }, id)
+}
+
+// Returns a resolved channel. Resolved channels are partial: they include the id, name, type,
+// permissions and, for threads, thread metadata and parent id.
+func (r *ResolvedData) Channel(id common.Snowflake) (common.Channel, bool) {
+	return lookupResolved(r, func(r *ResolvedData) *map[common.Snowflake]common.Channel { return r.Channels }, id)
+}
+
+// Returns a resolved message.
+func (r *ResolvedData) Message(id common.Snowflake) (common.Message, bool) {
+	return lookupResolved(r, func(r *ResolvedData) *map[common.Snowflake]common.Message { return r.Messages }, id)
+}
+
+// Returns a resolved attachment.
+func (r *ResolvedData) Attachment(id common.Snowflake) (common.MessageAttachment, bool) {
+	return lookupResolved(r, func(r *ResolvedData) *map[common.Snowflake]common.MessageAttachment { return r.Attachments }, id)
+}
+
+// Returns the object a snowflake-typed option refers to: *common.User (or *common.Member when
+// resolved in a guild) for USER, *common.Channel for CHANNEL, *common.Role for ROLE, a member, user
+// or role for MENTIONABLE and *common.MessageAttachment for ATTACHMENT. Returns nil when the option
+// holds no ID or its object was not resolved.
+func (r *ResolvedData) Resolve(option InteractionDataOption) any {
+
+	id, ok := option.SnowflakeValue()
+	if !ok {
+		return nil
+	}
+
+	switch option.Type {
+
+	case common.UserApplicationCommandOptionType, common.MentionableApplicationCommandOptionType:
+		if member, ok := r.Member(id); ok {
+			return &member
+		}
+		if user, ok := r.User(id); ok {
+			return &user
+		}
+		if option.Type == common.MentionableApplicationCommandOptionType {
+			if role, ok := r.Role(id); ok {
+				return &role
+			}
+		}
+
+	case common.ChannelApplicationCommandOptionType:
+		if channel, ok := r.Channel(id); ok {
+			return &channel
+		}
+
+	case common.RoleApplicationCommandOptionType:
+		if role, ok := r.Role(id); ok {
+			return &role
+		}
+
+	case common.AttachmentApplicationCommandOptionType:
+		if attachment, ok := r.Attachment(id); ok {
+			return &attachment
+		}
+	}
+
+	return nil
+}
+
+func lookupResolved[T any](r *ResolvedData, values func(*ResolvedData) *map[common.Snowflake]T, id common.Snowflake) (T, bool) {
+
+	var zero T
+	if r == nil {
+		return zero, false
+	}
+
+	resolved := values(r)
+	if resolved == nil {
+		return zero, false
+	}
+
+	value, ok := (*resolved)[id]
+	return value, ok
+}
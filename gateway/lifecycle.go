@@ -0,0 +1,117 @@
+package gateway
+
+import "brandenly.com/go/packages/discord-bot/common"
+
+// Synthetic dispatch events describing the state of gateway connections. They are delivered through
+// the same handlers as events sent by Discord but never appear on the wire.
+const (
+	ShardConnectingEvent     = "SHARD_CONNECTING"      // A shard is opening a websocket, for a new session or a resume
+	ShardReadyEvent          = "SHARD_READY"           // A shard received READY for a new session
+	ShardResumedEvent        = "SHARD_RESUMED"         // A shard resumed its previous session
+	ShardDisconnectedEvent   = "SHARD_DISCONNECTED"    // A shard's websocket closed
+	ShardInvalidSessionEvent = "SHARD_INVALID_SESSION" // Discord invalidated a shard's session
+	AllShardsReadyEvent      = "ALL_SHARDS_READY"      // Every shard has received READY at least once
+
+	GuildAvailableEvent    = "GUILD_AVAILABLE"     // A guild loaded after READY or recovered from an outage
+	GuildJoinedEvent       = "GUILD_JOINED"        // The bot was added to a guild
+	GuildUnavailableEvent  = "GUILD_UNAVAILABLE"   // A guild became unavailable due to an outage
+	GuildRemovedEvent      = "GUILD_REMOVED"       // The bot left or was removed from a guild
+	ShardGuildsLoadedEvent = "SHARD_GUILDS_LOADED" // Every guild listed in a shard's READY has loaded, or the wait timed out
+)
+
+// Data of a SHARD_CONNECTING event.
+type ShardConnecting struct {
+	Shard    int  `json:"shard"`    // Index of the shard
+	Resuming bool `json:"resuming"` // Whether the shard is resuming a previous session
+}
+
+// Data of a SHARD_READY event.
+type ShardReady struct {
+	Shard      int    `json:"shard"`       // Index of the shard
+	ShardCount int    `json:"shard_count"` // Total number of shards
+	SessionId  string `json:"session_id"`  // ID of the new session
+	Guilds     int    `json:"guilds"`      // Number of guilds assigned to the shard
+}
+
+// Data of a SHARD_RESUMED event.
+type ShardResumed struct {
+	Shard int `json:"shard"` // Index of the shard
+}
+
+// Data of a SHARD_DISCONNECTED event.
+type ShardDisconnected struct {
+	Shard     int    `json:"shard"`      // Index of the shard
+	CloseCode int    `json:"close_code"` // Websocket close code, zero if the connection dropped without one
+	Reason    string `json:"reason"`     // Close reason sent by Discord, or a description of the close code or error
+	Resumable bool   `json:"resumable"`  // Whether the session may be resumed after this close
+}
+
+// Data of a SHARD_INVALID_SESSION event.
+type ShardInvalidSession struct {
+	Shard     int  `json:"shard"`     // Index of the shard
+	Resumable bool `json:"resumable"` // Whether Discord indicated the session may be resumed
+}
+
+// Data of an ALL_SHARDS_READY event.
+type AllShardsReady struct {
+	ShardCount int `json:"shard_count"` // Total number of shards
+}
+
+// Data of a GUILD_AVAILABLE event.
+type GuildAvailable struct {
+	Shard   int              `json:"shard"`    // Index of the shard
+	GuildId common.Snowflake `json:"guild_id"` // ID of the guild
+	Initial bool             `json:"initial"`  // True when the guild is part of the shard's initial load, false when it recovered from an outage
+	Guild   *GuildCreate     `json:"guild"`    // The GUILD_CREATE payload
+}
+
+// Data of a GUILD_JOINED event.
+type GuildJoined struct {
+	Shard   int              `json:"shard"`    // Index of the shard
+	GuildId common.Snowflake `json:"guild_id"` // ID of the guild
+	Guild   *GuildCreate     `json:"guild"`    // The GUILD_CREATE payload
+}
+
+// Data of a GUILD_UNAVAILABLE event.
+type GuildUnavailable struct {
+	Shard   int              `json:"shard"`    // Index of the shard
+	GuildId common.Snowflake `json:"guild_id"` // ID of the guild
+}
+
+// Data of a GUILD_REMOVED event.
+type GuildRemoved struct {
+	Shard   int              `json:"shard"`    // Index of the shard
+	GuildId common.Snowflake `json:"guild_id"` // ID of the guild
+}
+
+// Data of a SHARD_GUILDS_LOADED event.
+type ShardGuildsLoaded struct {
+	Shard       int                `json:"shard"`       // Index of the shard
+	Loaded      int                `json:"loaded"`      // Number of guilds that loaded
+	Unavailable []common.Snowflake `json:"unavailable"` // IDs of guilds that had not loaded when the wait timed out
+	TimedOut    bool               `json:"timed_out"`   // Whether the wait timed out before every guild loaded
+}
+
+var LifecycleEventTypeStructs map[string]func() any = map[string]func() any{
+	ShardConnectingEvent:     func() any { return &ShardConnecting{} },
+	ShardReadyEvent:          func() any { return &ShardReady{} },
+	ShardResumedEvent:        func() any { return &ShardResumed{} },
+	ShardDisconnectedEvent:   func() any { return &ShardDisconnected{} },
+	ShardInvalidSessionEvent: func() any { return &ShardInvalidSession{} },
+	AllShardsReadyEvent:      func() any { return &AllShardsReady{} },
+	GuildAvailableEvent:      func() any { return &GuildAvailable{} },
+	GuildJoinedEvent:         func() any { return &GuildJoined{} },
+	GuildUnavailableEvent:    func() any { return &GuildUnavailable{} },
+	GuildRemovedEvent:        func() any { return &GuildRemoved{} },
+	ShardGuildsLoadedEvent:   func() any { return &ShardGuildsLoaded{} },
+}
+
+// Creates a synthetic dispatch event.
+func NewSyntheticEvent(eventType string, shard int, data any) Event {
+	return Event{
+		Op:    0,
+		T:     &eventType,
+		D:     data,
+		Shard: shard,
+	}
+}
@@ -0,0 +1,49 @@
+package gateway
+
+import "brandenly.com/go/packages/discord-bot/common"
+
+// Synthetic dispatch events enriching message events with the previous state of the message, taken
+// from the message cache. They follow the MESSAGE_UPDATE, MESSAGE_DELETE or MESSAGE_DELETE_BULK
+// event they were derived from.
+const (
+	MessageEditedEvent       = "MESSAGE_EDITED"        // A message was updated, with its previous version
+	MessageDeletedEvent      = "MESSAGE_DELETED"       // A message was deleted, with its last cached version
+	MessagesBulkDeletedEvent = "MESSAGES_BULK_DELETED" // Messages were bulk deleted, with their last cached versions
+)
+
+// Data of a MESSAGE_EDITED event.
+type MessageEdited struct {
+	Id        common.Snowflake  `json:"id"`                 // ID of the message
+	ChannelId common.Snowflake  `json:"channel_id"`         // ID of the channel
+	GuildId   *common.Snowflake `json:"guild_id,omitempty"` // ID of the guild
+	Author    common.User       `json:"author"`             // Author of the message
+	Member    *common.Member    `json:"member,omitempty"`   // Member properties of the author, when sent in a guild
+	Old       *common.Message   `json:"old"`                // Message before the update, nil if it was not cached
+	New       common.Message    `json:"new"`                // Message after the update
+	Edited    bool              `json:"edited"`             // Whether the author edited the message, rather than Discord updating it (e.g. embed unfurls)
+	Revisions []common.Message  `json:"revisions"`          // Earlier versions of the message recorded by the cache, oldest first
+}
+
+// Data of a MESSAGE_DELETED event.
+type MessageDeleted struct {
+	Id        common.Snowflake  `json:"id"`                 // ID of the message
+	ChannelId common.Snowflake  `json:"channel_id"`         // ID of the channel
+	GuildId   *common.Snowflake `json:"guild_id,omitempty"` // ID of the guild
+	Author    *common.User      `json:"author,omitempty"`   // Author of the message, nil if it was not cached
+	Old       *common.Message   `json:"old"`                // Message when it was deleted, nil if it was not cached
+	Revisions []common.Message  `json:"revisions"`          // Earlier versions of the message recorded by the cache, oldest first
+}
+
+// Data of a MESSAGES_BULK_DELETED event.
+type MessagesBulkDeleted struct {
+	Ids       []common.Snowflake `json:"ids"`                // IDs of the messages
+	ChannelId common.Snowflake   `json:"channel_id"`         // ID of the channel
+	GuildId   *common.Snowflake  `json:"guild_id,omitempty"` // ID of the guild
+	Old       []common.Message   `json:"old"`                // Cached messages among those deleted, oldest first
+}
+
+var MessageHistoryEventTypeStructs map[string]func() any = map[string]func() any{
+	MessageEditedEvent:       func() any { return &MessageEdited{} },
+	MessageDeletedEvent:      func() any { return &MessageDeleted{} },
+	MessagesBulkDeletedEvent: func() any { return &MessagesBulkDeleted{} },
+}
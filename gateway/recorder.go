@@ -0,0 +1,106 @@
+package gateway
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// A single raw gateway frame as written to a recording.
+type RecordedFrame struct {
+	Time  time.Time       `json:"time"`  // When the frame was received
+	Shard int             `json:"shard"` // Index of the shard that received the frame
+	Data  json.RawMessage `json:"data"`  // Raw frame payload exactly as received from the gateway
+}
+
+// Writes every raw frame received by a connection to a JSON Lines stream.
+// A single recorder may be shared by several connections.
+type Recorder struct {
+	mu     sync.Mutex
+	enc    *json.Encoder
+	closer io.Closer
+}
+
+// Creates a recorder that writes frames to w.
+func NewRecorder(w io.Writer) *Recorder {
+	r := &Recorder{enc: json.NewEncoder(w)}
+	if closer, ok := w.(io.Closer); ok {
+		r.closer = closer
+	}
+	return r
+}
+
+// Creates a recorder that appends frames to the file at path, creating it if needed.
+func CreateRecorder(path string) (*Recorder, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open recording file: %w", err)
+	}
+	return NewRecorder(file), nil
+}
+
+// Appends a raw frame received by the given shard to the recording.
+func (r *Recorder) Record(shard int, data []byte) error {
+
+	frame := RecordedFrame{
+		Time:  time.Now(),
+		Shard: shard,
+		Data:  json.RawMessage(data),
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.enc.Encode(frame); err != nil {
+		return fmt.Errorf("unable to record gateway frame: %w", err)
+	}
+
+	return nil
+}
+
+// Closes the underlying writer if it supports closing.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closer == nil {
+		return nil
+	}
+	return r.closer.Close()
+}
+
+// Reads a recording from r, calling fn for each frame in the order it was recorded.
+// Reading stops at the first error returned by fn.
+func ReadRecording(r io.Reader, fn func(RecordedFrame) error) error {
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024) // Guild create payloads can be very large
+
+	line := 0
+	for scanner.Scan() {
+		line++
+
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var frame RecordedFrame
+		if err := json.Unmarshal(scanner.Bytes(), &frame); err != nil {
+			return fmt.Errorf("unable to parse recorded frame on line %d: %w", line, err)
+		}
+
+		if err := fn(frame); err != nil {
+			return err
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("unable to read recording: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,288 @@
+package state
+
+import (
+	"slices"
+	"sync"
+
+	"brandenly.com/go/packages/discord-bot/common"
+	"brandenly.com/go/packages/discord-bot/gateway"
+)
+
+// Controls which entities a cache keeps.
+type Policy struct {
+	Kinds           map[Kind]bool // Entity kinds to cache; nil caches every kind
+	MaxGuildMembers int           // Only cache members, presences and voice states of guilds with at most this many members; zero for no limit
+
+	MaxChannelMessages  int // Messages cached per channel, defaults to DefaultMaxChannelMessages; negative for no limit
+	MaxMessageRevisions int // Earlier versions kept per cached message, defaults to DefaultMaxMessageRevisions; negative for no limit
+}
+
+// Reports whether the policy caches a kind of entity.
+func (p Policy) Caches(kind Kind) bool {
+	return p.Kinds == nil || p.Kinds[kind]
+}
+
+// A cache of guild state, kept up to date by passing it every dispatch event received. Safe for
+// concurrent use. Values returned by lookups are copies, but nested pointers are shared with the
+// cache and must not be modified.
+type Cache struct {
+	mu     sync.RWMutex
+	store  CacheStore
+	policy Policy
+
+	unavailable  map[common.Snowflake]bool             // Guilds currently unavailable due to an outage
+	channelScope map[common.Snowflake]common.Snowflake // Guild ID of each cached channel, so channels can be found by ID alone
+	memberCounts map[common.Snowflake]int              // Member count of each guild, for Policy.MaxGuildMembers
+}
+
+// Creates an empty in-memory cache that keeps every kind of entity, bounded by DefaultLimits.
+func New() *Cache {
+	return NewWithStore(NewMemoryStore(DefaultLimits), Policy{})
+}
+
+// Creates a cache backed by store. Entities already in the store, such as those restored by a
+// FileStore, are available immediately.
+func NewWithStore(store CacheStore, policy Policy) *Cache {
+
+	c := &Cache{
+		store:        store,
+		policy:       policy,
+		unavailable:  map[common.Snowflake]bool{},
+		channelScope: map[common.Snowflake]common.Snowflake{},
+		memberCounts: map[common.Snowflake]int{},
+	}
+
+	store.Range(KindChannel, 0, func(key Key, value any) bool {
+		c.channelScope[key.Id] = key.Scope
+		return true
+	})
+
+	return c
+}
+
+// Closes the underlying store.
+func (c *Cache) Close() error {
+	return c.store.Close()
+}
+
+//// Guilds
+
+// Returns a guild, including its roles, emojis and stickers.
+func (c *Cache) GetGuild(guildId common.Snowflake) (common.Guild, bool) {
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	guild, ok := get[common.Guild](c.store, KindGuild, Key{Id: guildId})
+	if !ok {
+		return common.Guild{}, false
+	}
+
+	return c.assembleGuild(guild), true
+}
+
+// Returns every cached guild, including unavailable guilds.
+func (c *Cache) Guilds() []common.Guild {
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var guilds []common.Guild
+	for _, guild := range all[common.Guild](c.store, KindGuild, 0) {
+		guilds = append(guilds, c.assembleGuild(guild))
+	}
+
+	return guilds
+}
+
+// Reports whether a guild is currently unavailable due to an outage.
+func (c *Cache) GuildUnavailable(guildId common.Snowflake) bool {
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.unavailable[guildId]
+}
+
+// Combines stored guild metadata with the guild's roles, emojis and stickers.
+func (c *Cache) assembleGuild(guild common.Guild) common.Guild {
+
+	guild.Roles = all[common.Role](c.store, KindRole, guild.Id)
+
+	if emojis, ok := get[[]common.Emoji](c.store, KindEmojis, Key{Id: guild.Id}); ok {
+		guild.Emojis = slices.Clone(emojis)
+	}
+
+	if stickers, ok := get[[]common.Sticker](c.store, KindStickers, Key{Id: guild.Id}); ok {
+		stickers = slices.Clone(stickers)
+		guild.Stickers = &stickers
+	}
+
+	return guild
+}
+
+//// Channels
+
+// Returns a channel or thread.
+func (c *Cache) GetChannel(channelId common.Snowflake) (common.Channel, bool) {
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return get[common.Channel](c.store, KindChannel, Key{Scope: c.channelScope[channelId], Id: channelId})
+}
+
+// Returns a guild's channels, excluding threads.
+func (c *Cache) GuildChannels(guildId common.Snowflake) []common.Channel {
+	return c.guildChannels(guildId, false)
+}
+
+// Returns a guild's active threads.
+func (c *Cache) GuildThreads(guildId common.Snowflake) []common.Channel {
+	return c.guildChannels(guildId, true)
+}
+
+func (c *Cache) guildChannels(guildId common.Snowflake, threads bool) []common.Channel {
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return slices.DeleteFunc(all[common.Channel](c.store, KindChannel, guildId), func(channel common.Channel) bool {
+		return IsThread(&channel) != threads
+	})
+}
+
+// Reports whether a channel is a thread.
+func IsThread(channel *common.Channel) bool {
+	switch channel.Type {
+	case 10, 11, 12: // ANNOUNCEMENT_THREAD, PUBLIC_THREAD, PRIVATE_THREAD
+		return true
+	}
+	return false
+}
+
+//// Roles
+
+// Returns a guild role.
+func (c *Cache) GetRole(guildId common.Snowflake, roleId common.Snowflake) (common.Role, bool) {
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return get[common.Role](c.store, KindRole, Key{Scope: guildId, Id: roleId})
+}
+
+// Returns a guild's roles.
+func (c *Cache) GuildRoles(guildId common.Snowflake) []common.Role {
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return all[common.Role](c.store, KindRole, guildId)
+}
+
+//// Members
+
+// Returns a guild member.
+func (c *Cache) GetMember(guildId common.Snowflake, userId common.Snowflake) (common.Member, bool) {
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return get[common.Member](c.store, KindMember, Key{Scope: guildId, Id: userId})
+}
+
+// Returns a guild's cached members. Large guilds only include members that have been seen or
+// requested with REQUEST_GUILD_MEMBERS.
+func (c *Cache) GuildMembers(guildId common.Snowflake) []common.Member {
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return all[common.Member](c.store, KindMember, guildId)
+}
+
+//// Emojis and stickers
+
+// Returns a guild's custom emojis.
+func (c *Cache) GuildEmojis(guildId common.Snowflake) []common.Emoji {
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	emojis, _ := get[[]common.Emoji](c.store, KindEmojis, Key{Id: guildId})
+	return slices.Clone(emojis)
+}
+
+// Returns a guild's custom stickers.
+func (c *Cache) GuildStickers(guildId common.Snowflake) []common.Sticker {
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	stickers, _ := get[[]common.Sticker](c.store, KindStickers, Key{Id: guildId})
+	return slices.Clone(stickers)
+}
+
+//// Voice states and presences
+
+// Returns a member's voice state, if they are connected to a voice channel.
+func (c *Cache) GetVoiceState(guildId common.Snowflake, userId common.Snowflake) (common.VoiceState, bool) {
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return get[common.VoiceState](c.store, KindVoiceState, Key{Scope: guildId, Id: userId})
+}
+
+// Returns the voice states of members connected to a guild's voice channels.
+func (c *Cache) GuildVoiceStates(guildId common.Snowflake) []common.VoiceState {
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return all[common.VoiceState](c.store, KindVoiceState, guildId)
+}
+
+// Returns a member's presence, if they are not offline.
+func (c *Cache) GetPresence(guildId common.Snowflake, userId common.Snowflake) (gateway.PresenceUpdate, bool) {
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return get[gateway.PresenceUpdate](c.store, KindPresence, Key{Scope: guildId, Id: userId})
+}
+
+//// Helpers
+
+// Returns a copy of a stored entity.
+func get[T any](store CacheStore, kind Kind, key Key) (T, bool) {
+
+	var zero T
+
+	value, ok := store.Get(kind, key)
+	if !ok {
+		return zero, false
+	}
+
+	entity, ok := value.(*T)
+	if !ok {
+		return zero, false
+	}
+
+	return *entity, true
+}
+
+// Returns copies of every stored entity of kind within scope.
+func all[T any](store CacheStore, kind Kind, scope common.Snowflake) []T {
+
+	var entities []T
+	store.Range(kind, scope, func(key Key, value any) bool {
+		if entity, ok := value.(*T); ok {
+			entities = append(entities, *entity)
+		}
+		return true
+	})
+
+	return entities
+}
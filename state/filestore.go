@@ -0,0 +1,402 @@
+package state
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"brandenly.com/go/packages/discord-bot/common"
+)
+
+const (
+	snapshotFileName = "snapshot.jsonl"
+	logFileName      = "append.log"
+
+	DefaultCompactAfter  = 50000       // Default number of log records written before the log is folded into a snapshot
+	DefaultFlushInterval = time.Second // Default time between writes of buffered changes to the log
+)
+
+// A single change recorded in a file store's snapshot or append log.
+type fileRecord struct {
+	Op     string          `json:"op"` // "put" or "delete"
+	Kind   Kind            `json:"kind"`
+	Key    Key             `json:"key"`
+	Value  json.RawMessage `json:"value,omitempty"`
+	Stored time.Time       `json:"stored,omitempty"`
+}
+
+// A change waiting to be written to the append log. Its value is encoded when it is written.
+type pendingRecord struct {
+	op     string
+	kind   Kind
+	key    Key
+	value  any
+	stored time.Time
+}
+
+// A CacheStore that serves reads from memory and persists every change to an append log in a
+// directory, so cached state survives restarts. Changes are buffered and written in the background,
+// where the log is also periodically folded into a snapshot, so storing never waits on the disk.
+// Values passed to Put must not be modified afterwards.
+type FileStore struct {
+	CompactAfter  int           // Records written to the log before it is compacted, defaults to DefaultCompactAfter
+	FlushInterval time.Duration // Time between writes of buffered changes to the log, defaults to DefaultFlushInterval
+	Logger        *log.Logger   // Receives persistence errors; errors are discarded when unset
+
+	memory *MemoryStore
+	dir    string
+
+	mu      sync.Mutex // Guards the buffered changes and the background writer's lifecycle
+	pending []pendingRecord
+	done    chan struct{} // Closed to stop the background writer, which is started by the first change
+	stopped chan struct{}
+	closed  bool
+
+	writeMu sync.Mutex // Held while writing to the log or the snapshot
+	log     *os.File
+	writer  *bufio.Writer
+	records int
+}
+
+// Opens the file store in dir, creating the directory if needed and restoring any previously
+// persisted entities.
+func OpenFileStore(dir string, limits map[Kind]Limits) (*FileStore, error) {
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("unable to create cache directory: %w", err)
+	}
+
+	s := &FileStore{
+		memory: NewMemoryStore(limits),
+		dir:    dir,
+	}
+
+	// Restore the snapshot, then the changes made since it was taken
+	for _, name := range []string{snapshotFileName, logFileName} {
+		if err := s.restore(filepath.Join(dir, name)); err != nil {
+			return nil, err
+		}
+	}
+
+	file, err := os.OpenFile(filepath.Join(dir, logFileName), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open cache log: %w", err)
+	}
+
+	s.log = file
+	s.writer = bufio.NewWriter(file)
+
+	return s, nil
+}
+
+func (s *FileStore) Get(kind Kind, key Key) (any, bool) {
+	return s.memory.Get(kind, key)
+}
+
+func (s *FileStore) Put(kind Kind, key Key, value any) {
+
+	now := time.Now()
+	s.memory.putAt(kind, key, value, now)
+
+	s.append(pendingRecord{op: "put", kind: kind, key: key, value: value, stored: now})
+}
+
+func (s *FileStore) Delete(kind Kind, key Key) {
+	s.memory.Delete(kind, key)
+	s.append(pendingRecord{op: "delete", kind: kind, key: key})
+}
+
+func (s *FileStore) DeleteScope(kind Kind, scope common.Snowflake) {
+
+	var keys []Key
+	s.memory.Range(kind, scope, func(key Key, value any) bool {
+		keys = append(keys, key)
+		return true
+	})
+
+	s.memory.DeleteScope(kind, scope)
+
+	records := make([]pendingRecord, 0, len(keys))
+	for _, key := range keys {
+		records = append(records, pendingRecord{op: "delete", kind: kind, key: key})
+	}
+	s.append(records...)
+}
+
+func (s *FileStore) Range(kind Kind, scope common.Snowflake, fn func(key Key, value any) bool) {
+	s.memory.Range(kind, scope, fn)
+}
+
+// Stops writing in the background, compacts the log into a snapshot and closes the store. Closing
+// the store again does nothing.
+func (s *FileStore) Close() error {
+
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closed = true
+	done, stopped := s.done, s.stopped
+	s.done = nil
+	s.mu.Unlock()
+
+	if done != nil {
+		close(done)
+		<-stopped
+	}
+
+	err := s.Compact()
+
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	return errors.Join(err, s.log.Close())
+}
+
+// Writes buffered changes to the append log.
+func (s *FileStore) Flush() error {
+
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	s.mu.Lock()
+	records := s.pending
+	s.pending = nil
+	s.mu.Unlock()
+
+	if len(records) == 0 {
+		return nil
+	}
+
+	var encodeErr error
+	for _, record := range records {
+
+		line, err := encodeRecord(record)
+		if err == nil {
+			_, err = s.writer.Write(line)
+		}
+		if err != nil {
+			encodeErr = errors.Join(encodeErr, fmt.Errorf("%s %s: %w", record.kind, record.key.Id, err))
+			continue
+		}
+
+		s.records++
+	}
+
+	if err := errors.Join(encodeErr, s.writer.Flush()); err != nil {
+		return fmt.Errorf("unable to append to cache log: %w", err)
+	}
+
+	return nil
+}
+
+// Writes every live entity to a new snapshot and truncates the append log.
+func (s *FileStore) Compact() error {
+
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	// Buffered changes were applied to memory before they were buffered, so the snapshot includes them
+	s.mu.Lock()
+	s.pending = nil
+	var entries []pendingRecord
+	for kind := range KindTypes {
+		s.memory.mu.Lock()
+		for element := s.memory.kind(kind).order.Back(); element != nil; element = element.Prev() { // Oldest first, preserving recency on restore
+			entry := element.Value.(*memoryEntry)
+			entries = append(entries, pendingRecord{op: "put", kind: kind, key: entry.key, value: entry.value, stored: entry.stored})
+		}
+		s.memory.mu.Unlock()
+	}
+	s.mu.Unlock()
+
+	temporary := filepath.Join(s.dir, snapshotFileName+".tmp")
+
+	file, err := os.Create(temporary)
+	if err != nil {
+		return fmt.Errorf("unable to create cache snapshot: %w", err)
+	}
+
+	writer := bufio.NewWriter(file)
+
+	var encodeErr error
+	for _, entry := range entries {
+
+		line, err := encodeRecord(entry)
+		if err == nil {
+			_, err = writer.Write(line)
+		}
+		if err != nil {
+			encodeErr = err
+		}
+	}
+
+	if err := errors.Join(encodeErr, writer.Flush(), file.Close()); err != nil {
+		os.Remove(temporary)
+		return fmt.Errorf("unable to write cache snapshot: %w", err)
+	}
+
+	if err := os.Rename(temporary, filepath.Join(s.dir, snapshotFileName)); err != nil {
+		return fmt.Errorf("unable to replace cache snapshot: %w", err)
+	}
+
+	// Changes up to now are in the snapshot
+	s.writer.Reset(s.log)
+	if err := s.log.Truncate(0); err != nil {
+		return fmt.Errorf("unable to truncate cache log: %w", err)
+	}
+	s.records = 0
+
+	return nil
+}
+
+// Buffers changes for the background writer, starting it if needed.
+func (s *FileStore) append(records ...pendingRecord) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return
+	}
+
+	s.pending = append(s.pending, records...)
+
+	if s.done == nil {
+		s.done = make(chan struct{})
+		s.stopped = make(chan struct{})
+		go s.run(s.done, s.stopped)
+	}
+}
+
+// Writes buffered changes every FlushInterval, compacting once the log grows past CompactAfter
+// records, until done is closed.
+func (s *FileStore) run(done chan struct{}, stopped chan struct{}) {
+	defer close(stopped)
+
+	interval := s.FlushInterval
+	if interval <= 0 {
+		interval = DefaultFlushInterval
+	}
+
+	compactAfter := s.CompactAfter
+	if compactAfter <= 0 {
+		compactAfter = DefaultCompactAfter
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+
+		case <-done:
+			return
+
+		case <-ticker.C:
+			if err := s.Flush(); err != nil {
+				s.logf("%s", err.Error())
+			}
+
+			s.writeMu.Lock()
+			due := s.records >= compactAfter
+			s.writeMu.Unlock()
+
+			if due {
+				if err := s.Compact(); err != nil {
+					s.logf("Unable to compact cache log: %s", err.Error())
+				}
+			}
+		}
+	}
+}
+
+// Returns a change encoded as a line of the log or snapshot.
+func encodeRecord(record pendingRecord) ([]byte, error) {
+
+	encoded := fileRecord{Op: record.op, Kind: record.kind, Key: record.key, Stored: record.stored}
+
+	if record.value != nil {
+		value, err := json.Marshal(record.value)
+		if err != nil {
+			return nil, err
+		}
+		encoded.Value = value
+	}
+
+	line, err := json.Marshal(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(line, '\n'), nil
+}
+
+// Applies the records in a snapshot or log file to memory. A missing file is not an error, and a
+// truncated final record (from a crash mid-write) is ignored.
+func (s *FileStore) restore(path string) error {
+
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("unable to open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			s.apply(line)
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("unable to read %s: %w", path, err)
+		}
+	}
+}
+
+func (s *FileStore) apply(line []byte) {
+
+	var record fileRecord
+	if err := json.Unmarshal(line, &record); err != nil {
+		return
+	}
+
+	switch record.Op {
+
+	case "put":
+		constructor, ok := KindTypes[record.Kind]
+		if !ok {
+			return
+		}
+
+		value := constructor()
+		if err := json.Unmarshal(record.Value, value); err != nil {
+			return
+		}
+
+		s.memory.putAt(record.Kind, record.Key, value, record.Stored)
+
+	case "delete":
+		s.memory.Delete(record.Kind, record.Key)
+	}
+}
+
+func (s *FileStore) logf(format string, args ...any) {
+	if s.Logger != nil {
+		s.Logger.Printf(format, args...)
+	}
+}
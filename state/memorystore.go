@@ -0,0 +1,189 @@
+package state
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"brandenly.com/go/packages/discord-bot/common"
+)
+
+// A CacheStore holding entities in memory, with optional per-kind LRU and TTL eviction.
+type MemoryStore struct {
+	mu     sync.Mutex
+	limits map[Kind]Limits
+	kinds  map[Kind]*memoryKind
+}
+
+// Entities of a single kind, indexed by scope and ordered by recency of use.
+type memoryKind struct {
+	scopes map[common.Snowflake]map[common.Snowflake]*list.Element
+	order  *list.List // Most recently used at the front
+}
+
+type memoryEntry struct {
+	key    Key
+	value  any
+	stored time.Time
+}
+
+// Creates an empty memory store applying the given eviction limits; kinds without limits are kept
+// until deleted.
+func NewMemoryStore(limits map[Kind]Limits) *MemoryStore {
+	return &MemoryStore{
+		limits: limits,
+		kinds:  map[Kind]*memoryKind{},
+	}
+}
+
+func (s *MemoryStore) Get(kind Kind, key Key) (any, bool) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries := s.kind(kind)
+
+	element, ok := entries.scopes[key.Scope][key.Id]
+	if !ok {
+		return nil, false
+	}
+
+	entry := element.Value.(*memoryEntry)
+	if s.expired(kind, entry) {
+		entries.remove(element)
+		return nil, false
+	}
+
+	entries.order.MoveToFront(element)
+
+	return entry.value, true
+}
+
+func (s *MemoryStore) Put(kind Kind, key Key, value any) {
+	s.putAt(kind, key, value, time.Now())
+}
+
+// Stores an entity as if it had been stored at the given time, so restored entities keep their age.
+func (s *MemoryStore) putAt(kind Kind, key Key, value any, stored time.Time) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries := s.kind(kind)
+
+	scope, ok := entries.scopes[key.Scope]
+	if !ok {
+		scope = map[common.Snowflake]*list.Element{}
+		entries.scopes[key.Scope] = scope
+	}
+
+	if element, ok := scope[key.Id]; ok {
+		entry := element.Value.(*memoryEntry)
+		entry.value = value
+		entry.stored = stored
+		entries.order.MoveToFront(element)
+		return
+	}
+
+	scope[key.Id] = entries.order.PushFront(&memoryEntry{key: key, value: value, stored: stored})
+
+	// Evict least recently used entities
+	if limit := s.limits[kind].MaxEntries; limit > 0 {
+		for entries.order.Len() > limit {
+			entries.remove(entries.order.Back())
+		}
+	}
+}
+
+func (s *MemoryStore) Delete(kind Kind, key Key) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries := s.kind(kind)
+	if element, ok := entries.scopes[key.Scope][key.Id]; ok {
+		entries.remove(element)
+	}
+}
+
+func (s *MemoryStore) DeleteScope(kind Kind, scope common.Snowflake) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries := s.kind(kind)
+	for _, element := range entries.scopes[scope] {
+		entries.order.Remove(element)
+	}
+	delete(entries.scopes, scope)
+}
+
+func (s *MemoryStore) Range(kind Kind, scope common.Snowflake, fn func(key Key, value any) bool) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries := s.kind(kind)
+
+	visit := func(elements map[common.Snowflake]*list.Element) bool {
+		for _, element := range elements {
+
+			entry := element.Value.(*memoryEntry)
+			if s.expired(kind, entry) {
+				entries.remove(element)
+				continue
+			}
+
+			if !fn(entry.key, entry.value) {
+				return false
+			}
+		}
+		return true
+	}
+
+	if scope != 0 {
+		visit(entries.scopes[scope])
+		return
+	}
+
+	for _, elements := range entries.scopes {
+		if !visit(elements) {
+			return
+		}
+	}
+}
+
+func (s *MemoryStore) Close() error {
+	return nil
+}
+
+// Returns the entities of a kind, creating the index if needed.
+func (s *MemoryStore) kind(kind Kind) *memoryKind {
+
+	entries, ok := s.kinds[kind]
+	if !ok {
+		entries = &memoryKind{scopes: map[common.Snowflake]map[common.Snowflake]*list.Element{}, order: list.New()}
+		s.kinds[kind] = entries
+	}
+
+	return entries
+}
+
+// Reports whether an entity has outlived its kind's TTL.
+func (s *MemoryStore) expired(kind Kind, entry *memoryEntry) bool {
+	ttl := s.limits[kind].TTL
+	return ttl > 0 && time.Since(entry.stored) > ttl
+}
+
+// Removes an element from the recency list and its scope index.
+func (k *memoryKind) remove(element *list.Element) {
+
+	entry := element.Value.(*memoryEntry)
+	k.order.Remove(element)
+
+	scope := k.scopes[entry.key.Scope]
+	delete(scope, entry.key.Id)
+	if len(scope) == 0 {
+		delete(k.scopes, entry.key.Scope)
+	}
+}
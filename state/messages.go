@@ -0,0 +1,274 @@
+package state
+
+import (
+	"slices"
+	"time"
+
+	"brandenly.com/go/packages/discord-bot/common"
+	"brandenly.com/go/packages/discord-bot/gateway"
+)
+
+const (
+	DefaultMaxChannelMessages  = 100 // Default number of messages cached per channel
+	DefaultMaxMessageRevisions = 10  // Default number of earlier versions kept per cached message
+)
+
+// A cached message along with the versions it had before being edited.
+type CachedMessage struct {
+	common.Message
+	GuildId   *common.Snowflake `json:"guild_id,omitempty"`  // ID of the guild the message was sent in
+	Revisions []common.Message  `json:"revisions,omitempty"` // Earlier versions of the message, oldest first
+}
+
+//// Lookups
+
+// Returns a cached message.
+func (c *Cache) GetMessage(channelId common.Snowflake, messageId common.Snowflake) (common.Message, bool) {
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	cached, ok := get[CachedMessage](c.store, KindMessage, Key{Scope: channelId, Id: messageId})
+	return cached.Message, ok
+}
+
+// Returns a channel's cached messages, oldest first.
+func (c *Cache) ChannelMessages(channelId common.Snowflake) []common.Message {
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var messages []common.Message
+	for _, cached := range all[CachedMessage](c.store, KindMessage, channelId) {
+		messages = append(messages, cached.Message)
+	}
+
+	slices.SortFunc(messages, func(a, b common.Message) int {
+		return a.Timestamp.Compare(b.Timestamp)
+	})
+
+	return messages
+}
+
+// Returns the earlier versions of a cached message, oldest first.
+func (c *Cache) MessageRevisions(channelId common.Snowflake, messageId common.Snowflake) []common.Message {
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	cached, _ := get[CachedMessage](c.store, KindMessage, Key{Scope: channelId, Id: messageId})
+	return slices.Clone(cached.Revisions)
+}
+
+//// Updates
+
+func (c *Cache) messageCreate(data *gateway.MessageCreate) {
+
+	if !c.policy.Caches(KindMessage) {
+		return
+	}
+
+	cached := CachedMessage{Message: messageFromCreate(data), GuildId: data.GuildId}
+	c.store.Put(KindMessage, Key{Scope: data.ChannelId, Id: data.Id}, &cached)
+
+	c.trimChannelMessages(data.ChannelId)
+}
+
+// Records an update as a new revision of the cached message, returning a MESSAGE_EDITED event with
+// the message's previous version.
+func (c *Cache) messageUpdate(shard int, data *gateway.MessageUpdate) []gateway.Event {
+
+	key := Key{Scope: data.ChannelId, Id: data.Id}
+	message := messageFromCreate((*gateway.MessageCreate)(data))
+
+	cached, ok := get[CachedMessage](c.store, KindMessage, key)
+
+	var old *common.Message
+	if ok {
+		previous := cached.Message
+		old = &previous
+	}
+
+	edited := isEdit(old, &message)
+	if edited && old != nil {
+		cached.Revisions = append(slices.Clone(cached.Revisions), *old)
+
+		if limit := limitOr(c.policy.MaxMessageRevisions, DefaultMaxMessageRevisions); limit >= 0 && len(cached.Revisions) > limit {
+			cached.Revisions = cached.Revisions[len(cached.Revisions)-limit:]
+		}
+	}
+
+	cached.Message = message
+	cached.GuildId = data.GuildId
+
+	if c.policy.Caches(KindMessage) {
+		c.store.Put(KindMessage, key, &cached)
+		c.trimChannelMessages(data.ChannelId)
+	}
+
+	return []gateway.Event{
+		gateway.NewSyntheticEvent(gateway.MessageEditedEvent, shard, &gateway.MessageEdited{
+			Id:        data.Id,
+			ChannelId: data.ChannelId,
+			GuildId:   data.GuildId,
+			Author:    data.Author,
+			Member:    data.Member,
+			Old:       old,
+			New:       message,
+			Edited:    edited,
+			Revisions: slices.Clone(cached.Revisions),
+		}),
+	}
+}
+
+// Removes a deleted message, returning a MESSAGE_DELETED event with its last cached version.
+func (c *Cache) messageDelete(shard int, data *gateway.MessageDelete) []gateway.Event {
+
+	deleted := &gateway.MessageDeleted{
+		Id:        data.Id,
+		ChannelId: data.ChannelId,
+		GuildId:   data.GuildId,
+	}
+
+	if cached, ok := c.takeMessage(data.ChannelId, data.Id); ok {
+		deleted.Author = &cached.Author
+		deleted.Old = &cached.Message
+		deleted.Revisions = cached.Revisions
+	}
+
+	return []gateway.Event{gateway.NewSyntheticEvent(gateway.MessageDeletedEvent, shard, deleted)}
+}
+
+// Removes bulk deleted messages, returning a MESSAGES_BULK_DELETED event with their last cached versions.
+func (c *Cache) messageDeleteBulk(shard int, data *gateway.MessageDeleteBulk) []gateway.Event {
+
+	deleted := &gateway.MessagesBulkDeleted{
+		Ids:       data.Ids,
+		ChannelId: data.ChannelId,
+		GuildId:   data.GuildId,
+	}
+
+	for _, id := range data.Ids {
+		if cached, ok := c.takeMessage(data.ChannelId, id); ok {
+			deleted.Old = append(deleted.Old, cached.Message)
+		}
+	}
+
+	slices.SortFunc(deleted.Old, func(a, b common.Message) int {
+		return a.Timestamp.Compare(b.Timestamp)
+	})
+
+	return []gateway.Event{gateway.NewSyntheticEvent(gateway.MessagesBulkDeletedEvent, shard, deleted)}
+}
+
+// Removes and returns a cached message.
+func (c *Cache) takeMessage(channelId common.Snowflake, messageId common.Snowflake) (CachedMessage, bool) {
+
+	key := Key{Scope: channelId, Id: messageId}
+
+	cached, ok := get[CachedMessage](c.store, KindMessage, key)
+	if ok {
+		c.store.Delete(KindMessage, key)
+	}
+
+	return cached, ok
+}
+
+// Evicts a channel's oldest messages beyond Policy.MaxChannelMessages.
+func (c *Cache) trimChannelMessages(channelId common.Snowflake) {
+
+	limit := limitOr(c.policy.MaxChannelMessages, DefaultMaxChannelMessages)
+	if limit < 0 {
+		return
+	}
+
+	type sent struct {
+		key       Key
+		timestamp time.Time
+	}
+
+	var messages []sent
+	c.store.Range(KindMessage, channelId, func(key Key, value any) bool {
+		if cached, ok := value.(*CachedMessage); ok {
+			messages = append(messages, sent{key, cached.Timestamp})
+		}
+		return true
+	})
+
+	if len(messages) <= limit {
+		return
+	}
+
+	slices.SortFunc(messages, func(a, b sent) int {
+		return a.timestamp.Compare(b.timestamp)
+	})
+
+	for _, message := range messages[:len(messages)-limit] {
+		c.store.Delete(KindMessage, message.key)
+	}
+}
+
+// Reports whether an update changed what the author wrote, as opposed to Discord updating the
+// message, such as when unfurling links into embeds.
+func isEdit(old *common.Message, updated *common.Message) bool {
+
+	if old == nil {
+		return updated.EditedTimestamp != nil
+	}
+
+	if updated.Content != old.Content {
+		return true
+	}
+
+	if updated.EditedTimestamp == nil {
+		return false
+	}
+
+	return old.EditedTimestamp == nil || !updated.EditedTimestamp.Equal(*old.EditedTimestamp)
+}
+
+// Returns limit, or fallback when limit is unset.
+func limitOr(limit int, fallback int) int {
+	if limit == 0 {
+		return fallback
+	}
+	return limit
+}
+
+func messageFromCreate(data *gateway.MessageCreate) common.Message {
+	return common.Message{
+		Id:                  data.Id,
+		ChannelId:           data.ChannelId,
+		Author:              data.Author,
+		Content:             data.Content,
+		Timestamp:           data.Timestamp,
+		EditedTimestamp:     data.EditedTimestamp,
+		Tts:                 data.Tts,
+		MentionEveryone:     data.MentionEveryone,
+		Mentions:            data.Mentions,
+		MentionRoles:        data.MentionRoles,
+		MentionChannels:     data.MentionChannels,
+		ReferencedMessage:   data.ReferencedMessage,
+		Attachments:         data.Attachments,
+		Embeds:              data.Embeds,
+		Interaction:         messageInteraction(data.Interaction),
+		InteractionMetaData: messageInteraction(data.InteractionMetaData),
+		AllowedMentions:     data.AllowedMentions,
+		Components:          data.Components,
+		Flags:               data.Flags,
+	}
+}
+
+func messageInteraction(interaction *gateway.MessageInteraction) *common.MessageInteraction {
+
+	if interaction == nil {
+		return nil
+	}
+
+	return &common.MessageInteraction{
+		Id:   interaction.Id,
+		Type: int(interaction.Type),
+		Name: interaction.Name,
+		User: interaction.User,
+	}
+}
@@ -0,0 +1,67 @@
+package state
+
+import (
+	"brandenly.com/go/packages/discord-bot/common"
+)
+
+// Returns a member's guild-wide permissions, computed from the cached guild, roles and member.
+func (c *Cache) MemberPermissions(guildId common.Snowflake, userId common.Snowflake) (common.Permissions, bool) {
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	guild, member, ok := c.permissionSubjects(guildId, userId)
+	if !ok {
+		return 0, false
+	}
+
+	return common.BasePermissions(&guild, &member), true
+}
+
+// Returns a member's permissions in a channel or thread, computed from cached state. Threads use
+// their parent channel's overwrites.
+func (c *Cache) ChannelPermissions(channelId common.Snowflake, userId common.Snowflake) (common.Permissions, bool) {
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	channel, ok := get[common.Channel](c.store, KindChannel, Key{Scope: c.channelScope[channelId], Id: channelId})
+	if !ok || channel.GuildId == nil {
+		return 0, false
+	}
+
+	if IsThread(&channel) {
+		if channel.ParentId == nil {
+			return 0, false
+		}
+
+		channel, ok = get[common.Channel](c.store, KindChannel, Key{Scope: *channel.GuildId, Id: *channel.ParentId})
+		if !ok {
+			return 0, false
+		}
+	}
+
+	guild, member, ok := c.permissionSubjects(*channel.GuildId, userId)
+	if !ok {
+		return 0, false
+	}
+
+	return common.EffectivePermissions(&guild, &member, &channel), true
+}
+
+// Returns the cached guild, with its roles, and member needed to compute permissions.
+func (c *Cache) permissionSubjects(guildId common.Snowflake, userId common.Snowflake) (common.Guild, common.Member, bool) {
+
+	guild, ok := get[common.Guild](c.store, KindGuild, Key{Id: guildId})
+	if !ok {
+		return common.Guild{}, common.Member{}, false
+	}
+	guild.Roles = all[common.Role](c.store, KindRole, guildId)
+
+	member, ok := get[common.Member](c.store, KindMember, Key{Scope: guildId, Id: userId})
+	if !ok {
+		return common.Guild{}, common.Member{}, false
+	}
+
+	return guild, member, true
+}
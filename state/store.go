@@ -0,0 +1,71 @@
+package state
+
+import (
+	"time"
+
+	"brandenly.com/go/packages/discord-bot/common"
+	"brandenly.com/go/packages/discord-bot/gateway"
+)
+
+// A type of entity held in a cache store.
+type Kind string
+
+const (
+	KindGuild      Kind = "guild"       // *common.Guild keyed by guild ID, without roles, emojis or stickers
+	KindChannel    Kind = "channel"     // *common.Channel scoped by guild ID (zero for DMs), keyed by channel ID
+	KindRole       Kind = "role"        // *common.Role scoped by guild ID, keyed by role ID
+	KindMember     Kind = "member"      // *common.Member scoped by guild ID, keyed by user ID
+	KindEmojis     Kind = "emojis"      // *[]common.Emoji keyed by guild ID
+	KindStickers   Kind = "stickers"    // *[]common.Sticker keyed by guild ID
+	KindVoiceState Kind = "voice_state" // *common.VoiceState scoped by guild ID, keyed by user ID
+	KindPresence   Kind = "presence"    // *gateway.PresenceUpdate scoped by guild ID, keyed by user ID
+	KindMessage    Kind = "message"     // *CachedMessage scoped by channel ID, keyed by message ID
+)
+
+// Constructors for the value stored under each kind, used when decoding persisted entries.
+var KindTypes map[Kind]func() any = map[Kind]func() any{
+	KindGuild:      func() any { return &common.Guild{} },
+	KindChannel:    func() any { return &common.Channel{} },
+	KindRole:       func() any { return &common.Role{} },
+	KindMember:     func() any { return &common.Member{} },
+	KindEmojis:     func() any { return &[]common.Emoji{} },
+	KindStickers:   func() any { return &[]common.Sticker{} },
+	KindVoiceState: func() any { return &common.VoiceState{} },
+	KindPresence:   func() any { return &gateway.PresenceUpdate{} },
+	KindMessage:    func() any { return &CachedMessage{} },
+}
+
+// Identifies a stored entity. Scope groups related entities, such as the members of one guild, and
+// is zero for entities that are not scoped.
+type Key struct {
+	Scope common.Snowflake `json:"scope,omitempty"`
+	Id    common.Snowflake `json:"id"`
+}
+
+// Keyed entity storage backing a Cache. Values are pointers of the type documented on each Kind.
+// Implementations must be safe for concurrent use.
+type CacheStore interface {
+	Get(kind Kind, key Key) (any, bool)
+	Put(kind Kind, key Key, value any)
+	Delete(kind Kind, key Key)
+
+	// Removes every entity of kind within scope.
+	DeleteScope(kind Kind, scope common.Snowflake)
+
+	// Calls fn for every entity of kind within scope, or for every entity of kind when scope is zero,
+	// until fn returns false. fn must not call back into the store.
+	Range(kind Kind, scope common.Snowflake, fn func(key Key, value any) bool)
+
+	Close() error
+}
+
+// Eviction limits for a kind of entity. A zero value keeps entities until they are deleted.
+type Limits struct {
+	MaxEntries int           // Evict the least recently used entities beyond this many; zero for no limit
+	TTL        time.Duration // Expire entities this long after they were stored; zero for no expiry
+}
+
+// Eviction limits used by New: messages are bounded, other entities are kept until deleted.
+var DefaultLimits map[Kind]Limits = map[Kind]Limits{
+	KindMessage: {MaxEntries: 10000, TTL: 24 * time.Hour},
+}
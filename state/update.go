@@ -0,0 +1,414 @@
+package state
+
+import (
+	"slices"
+
+	"brandenly.com/go/packages/discord-bot/common"
+	"brandenly.com/go/packages/discord-bot/gateway"
+)
+
+// Applies a dispatch event to the cache, returning any enriched events derived from the previously
+// cached state (see gateway.MessageHistoryEventTypeStructs). Events that do not affect cached state
+// are ignored.
+func (c *Cache) Handle(event *gateway.Event) []gateway.Event {
+
+	if event.Op != 0 || event.T == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch data := event.D.(type) {
+
+	case *gateway.GuildCreate:
+		c.guildCreate(data)
+
+	case *common.Guild: // GUILD_UPDATE
+		c.guildUpdate(data)
+
+	case *common.UnavailableGuild: // GUILD_DELETE
+		c.guildDelete(data)
+
+	case *common.Channel: // CHANNEL_* and THREAD_*
+		switch *event.T {
+		case "CHANNEL_DELETE", "THREAD_DELETE":
+			c.deleteChannel(data.Id)
+		default:
+			c.putChannel(data, nil)
+		}
+
+	case *gateway.ThreadListSync:
+		c.threadListSync(data)
+
+	case *gateway.GuildRoleCreate:
+		c.putRole(data.GuildId, data.Role)
+
+	case *gateway.GuildRoleUpdate:
+		c.putRole(data.GuildId, data.Role)
+
+	case *gateway.GuildRoleDelete:
+		c.store.Delete(KindRole, Key{Scope: data.GuildId, Id: data.RoleId})
+
+	case *gateway.GuildMemberAdd:
+		c.memberAdd(data)
+
+	case *gateway.GuildMemberUpdate:
+		c.memberUpdate(data)
+
+	case *gateway.GuildMemberRemove:
+		c.memberRemove(data)
+
+	case *gateway.GuildMembersChunk:
+		c.putMembers(data.GuildId, data.Members)
+		if data.Presences != nil {
+			c.putPresences(data.GuildId, *data.Presences)
+		}
+
+	case *gateway.GuildEmojisUpdate:
+		emojis := slices.Clone(data.Emojis)
+		c.put(KindEmojis, Key{Id: data.GuildId}, &emojis)
+
+	case *gateway.GuildStickersUpdate:
+		stickers := slices.Clone(data.Stickers)
+		c.put(KindStickers, Key{Id: data.GuildId}, &stickers)
+
+	case *common.VoiceState: // VOICE_STATE_UPDATE
+		if data.GuildId != nil {
+			c.putVoiceState(*data.GuildId, data)
+		}
+
+	case *gateway.PresenceUpdate:
+		c.putPresences(data.GuildId, []gateway.PresenceUpdate{*data})
+
+	case *gateway.MessageCreate:
+		c.messageCreate(data)
+
+	case *gateway.MessageUpdate:
+		return c.messageUpdate(event.Shard, data)
+
+	case *gateway.MessageDelete:
+		return c.messageDelete(event.Shard, data)
+
+	case *gateway.MessageDeleteBulk:
+		return c.messageDeleteBulk(event.Shard, data)
+	}
+
+	return nil
+}
+
+// Stores an entity if the cache policy keeps its kind. Callers pass copies rather than pointers into
+// event payloads, so that the cache neither changes what handlers receive nor shares it with them.
+func (c *Cache) put(kind Kind, key Key, value any) {
+	if c.policy.Caches(kind) {
+		c.store.Put(kind, key, value)
+	}
+}
+
+// Reports whether the cache policy keeps per-member state for a guild.
+func (c *Cache) cachesMembersOf(guildId common.Snowflake) bool {
+
+	if c.policy.MaxGuildMembers <= 0 {
+		return true
+	}
+
+	count, ok := c.memberCounts[guildId]
+	return ok && count <= c.policy.MaxGuildMembers
+}
+
+//// Guilds
+
+func (c *Cache) guildCreate(data *gateway.GuildCreate) {
+
+	guildId := data.Id
+
+	if data.Unavailable != nil && *data.Unavailable {
+		c.unavailable[guildId] = true
+		return
+	}
+
+	delete(c.unavailable, guildId)
+
+	c.memberCounts[guildId] = data.Membercount
+
+	c.guildUpdate(&data.Guild)
+
+	// Full guild payloads replace previously cached state, but channels that are still there, such as
+	// after an outage, keep their cached messages
+	present := map[common.Snowflake]bool{}
+	for _, channel := range slices.Concat(data.Channel, data.Threads) {
+		present[channel.Id] = true
+	}
+
+	for channelId, scope := range c.channelScope {
+		if scope == guildId && !present[channelId] {
+			c.deleteChannel(channelId)
+		}
+	}
+
+	for i := range data.Channel {
+		c.putChannel(&data.Channel[i], &guildId)
+	}
+
+	for i := range data.Threads {
+		c.putChannel(&data.Threads[i], &guildId)
+	}
+
+	c.store.DeleteScope(KindMember, guildId)
+	c.putMembers(guildId, data.Members)
+
+	c.store.DeleteScope(KindVoiceState, guildId)
+	for i := range data.VoiceStates {
+		c.putVoiceState(guildId, &data.VoiceStates[i])
+	}
+
+	c.store.DeleteScope(KindPresence, guildId)
+	c.putPresences(guildId, data.Presences)
+}
+
+func (c *Cache) guildUpdate(data *common.Guild) {
+
+	guild := *data
+	guild.Roles = nil
+	guild.Emojis = nil
+	guild.Stickers = nil
+
+	c.put(KindGuild, Key{Id: guild.Id}, &guild)
+
+	c.store.DeleteScope(KindRole, guild.Id)
+	for _, role := range data.Roles {
+		c.putRole(guild.Id, role)
+	}
+
+	emojis := slices.Clone(data.Emojis)
+	c.put(KindEmojis, Key{Id: guild.Id}, &emojis)
+
+	if data.Stickers != nil {
+		stickers := slices.Clone(*data.Stickers)
+		c.put(KindStickers, Key{Id: guild.Id}, &stickers)
+	}
+}
+
+func (c *Cache) putRole(guildId common.Snowflake, role common.Role) {
+	c.put(KindRole, Key{Scope: guildId, Id: role.Id}, &role)
+}
+
+func (c *Cache) guildDelete(data *common.UnavailableGuild) {
+
+	// Outages keep cached state until the guild becomes available again
+	if data.Unavailable {
+		c.unavailable[data.Id] = true
+		return
+	}
+
+	delete(c.unavailable, data.Id)
+	delete(c.memberCounts, data.Id)
+
+	c.store.Delete(KindGuild, Key{Id: data.Id})
+	c.store.Delete(KindEmojis, Key{Id: data.Id})
+	c.store.Delete(KindStickers, Key{Id: data.Id})
+
+	for _, kind := range []Kind{KindRole, KindMember, KindVoiceState, KindPresence} {
+		c.store.DeleteScope(kind, data.Id)
+	}
+
+	c.deleteGuildChannels(data.Id)
+}
+
+//// Channels
+
+// Stores a copy of a channel, filling in the guild ID that GUILD_CREATE omits from nested channels.
+func (c *Cache) putChannel(data *common.Channel, guildId *common.Snowflake) {
+
+	channel := *data
+	if channel.GuildId == nil && guildId != nil {
+		channel.GuildId = guildId
+	}
+
+	if !c.policy.Caches(KindChannel) {
+		return
+	}
+
+	var scope common.Snowflake
+	if channel.GuildId != nil {
+		scope = *channel.GuildId
+	}
+
+	c.store.Put(KindChannel, Key{Scope: scope, Id: channel.Id}, &channel)
+	c.channelScope[channel.Id] = scope
+}
+
+// Removes a channel and its cached messages.
+func (c *Cache) deleteChannel(channelId common.Snowflake) {
+
+	scope, ok := c.channelScope[channelId]
+	if !ok {
+		return
+	}
+
+	c.store.Delete(KindChannel, Key{Scope: scope, Id: channelId})
+	c.store.DeleteScope(KindMessage, channelId)
+	delete(c.channelScope, channelId)
+}
+
+func (c *Cache) deleteGuildChannels(guildId common.Snowflake) {
+	for channelId, scope := range c.channelScope {
+		if scope == guildId {
+			c.deleteChannel(channelId)
+		}
+	}
+}
+
+func (c *Cache) threadListSync(data *gateway.ThreadListSync) {
+
+	// Threads in the synced parents (or the whole guild) are replaced by the threads in the payload
+	for _, channel := range all[common.Channel](c.store, KindChannel, data.GuildId) {
+
+		if !IsThread(&channel) {
+			continue
+		}
+
+		if data.ChannelIds != nil && (channel.ParentId == nil || !slices.Contains(*data.ChannelIds, *channel.ParentId)) {
+			continue
+		}
+
+		c.deleteChannel(channel.Id)
+	}
+
+	guildId := data.GuildId
+	for i := range data.Threads {
+		c.putChannel(&data.Threads[i], &guildId)
+	}
+}
+
+//// Members
+
+func (c *Cache) putMembers(guildId common.Snowflake, members []common.Member) {
+
+	if !c.cachesMembersOf(guildId) {
+		return
+	}
+
+	for _, member := range members {
+		if member.User != nil {
+			c.put(KindMember, Key{Scope: guildId, Id: member.User.Id}, &member)
+		}
+	}
+}
+
+func (c *Cache) memberAdd(data *gateway.GuildMemberAdd) {
+
+	if count, ok := c.memberCounts[data.GuildId]; ok {
+		c.memberCounts[data.GuildId] = count + 1
+	}
+
+	if data.User == nil {
+		return
+	}
+
+	c.putMembers(data.GuildId, []common.Member{{
+		User:                       data.User,
+		Nick:                       data.Nick,
+		Avatar:                     data.Avatar,
+		Banner:                     data.Banner,
+		Roles:                      data.Roles,
+		JoinedAt:                   data.JoinedAt,
+		PremiumSince:               data.PremiumSince,
+		Deaf:                       data.Deaf,
+		Mute:                       data.Mute,
+		Flags:                      data.Flags,
+		Pending:                    data.Pending,
+		Permissions:                data.Permissions,
+		CommunicationDisabledUntil: data.CommunicationDisabledUntil,
+		AvatarDecorationData:       data.AvatarDecorationData,
+	}})
+}
+
+// Merges a member update into the cached member, creating the member if it was not cached.
+func (c *Cache) memberUpdate(data *gateway.GuildMemberUpdate) {
+
+	key := Key{Scope: data.GuildId, Id: data.User.Id}
+
+	member, _ := get[common.Member](c.store, KindMember, key)
+
+	user := data.User
+	member.User = &user
+	member.Roles = data.Roles
+	member.Nick = data.Nick
+	member.Avatar = data.Avatar
+	member.Banner = data.Banner
+	member.PremiumSince = data.PremiumSince
+	member.Pending = data.Pending
+	member.CommunicationDisabledUntil = data.CommunicationDisabledUntil
+	member.AvatarDecorationData = data.AvatarDecorationData
+
+	if data.JoinedAt != nil {
+		member.JoinedAt = *data.JoinedAt
+	}
+	if data.Deaf != nil {
+		member.Deaf = *data.Deaf
+	}
+	if data.Mute != nil {
+		member.Mute = *data.Mute
+	}
+	if data.Flags != nil {
+		member.Flags = *data.Flags
+	}
+
+	c.putMembers(data.GuildId, []common.Member{member})
+}
+
+func (c *Cache) memberRemove(data *gateway.GuildMemberRemove) {
+
+	if count, ok := c.memberCounts[data.GuildId]; ok && count > 0 {
+		c.memberCounts[data.GuildId] = count - 1
+	}
+
+	key := Key{Scope: data.GuildId, Id: data.User.Id}
+	c.store.Delete(KindMember, key)
+	c.store.Delete(KindPresence, key)
+	c.store.Delete(KindVoiceState, key)
+}
+
+//// Voice states and presences
+
+// Stores a copy of a voice state, removing it when the member has left voice.
+func (c *Cache) putVoiceState(guildId common.Snowflake, data *common.VoiceState) {
+
+	key := Key{Scope: guildId, Id: data.UserId}
+
+	if data.ChannelId == nil {
+		c.store.Delete(KindVoiceState, key)
+		return
+	}
+
+	voiceState := *data
+	if voiceState.GuildId == nil {
+		voiceState.GuildId = &guildId
+	}
+
+	if c.cachesMembersOf(guildId) {
+		c.put(KindVoiceState, key, &voiceState)
+	}
+}
+
+// Stores presences, removing members that went offline.
+func (c *Cache) putPresences(guildId common.Snowflake, presences []gateway.PresenceUpdate) {
+
+	cached := c.cachesMembersOf(guildId)
+
+	for _, presence := range presences {
+
+		key := Key{Scope: guildId, Id: presence.User.Id}
+
+		if presence.Status == "offline" {
+			c.store.Delete(KindPresence, key)
+			continue
+		}
+
+		presence.GuildId = guildId
+		if cached {
+			c.put(KindPresence, key, &presence)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package textcommands
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"brandenly.com/go/packages/discord-bot/cooldown"
+)
+
+// Invoked for a text command.
+type Handler func(ctx *Context) error
+
+// A command invoked by a message starting with a prefix and the command's name or one of its
+// aliases, such as "!ban @user spamming".
+type Command struct {
+	Name        string           // Lowercase name the command is invoked by
+	Aliases     []string         // Other lowercase names the command is invoked by
+	Description string           // Short description of what the command does
+	Args        []Arg            // Arguments the command takes, in order; used for its usage
+	Usage       string           // Arguments shown in the command's usage, such as "<user> [reason...]"; derived from Args when empty
+	Handler     Handler          // Invoked with the command's arguments
+	Limits      *cooldown.Limits // Cooldowns and concurrency limits checked before Handler runs, which commands can share
+}
+
+// An argument of a command.
+type Arg struct {
+	Name        string // Name shown in the command's usage
+	Description string // Short description of the argument
+	Required    bool   // Whether the command fails without the argument
+	Rest        bool   // Whether the argument takes the rest of the message
+}
+
+// Returns the names the command is invoked by.
+func (c *Command) Names() []string {
+	return append([]string{c.Name}, c.Aliases...)
+}
+
+// Returns how the command is invoked with prefix, such as "!ban <user> [reason...]".
+func (c *Command) UsageLine(prefix string) string {
+
+	usage := c.Usage
+	if usage == "" {
+
+		args := make([]string, 0, len(c.Args))
+		for _, arg := range c.Args {
+
+			name := arg.Name
+			if arg.Rest {
+				name += "..."
+			}
+
+			if arg.Required {
+				args = append(args, "<"+name+">")
+			} else {
+				args = append(args, "["+name+"]")
+			}
+		}
+
+		usage = strings.Join(args, " ")
+	}
+
+	return strings.TrimSpace(prefix + c.Name + " " + usage)
+}
+
+// Reports whether the command's names and arguments are usable.
+func (c *Command) Validate() error {
+
+	for _, name := range c.Names() {
+		if name == "" || strings.ContainsFunc(name, unicode.IsSpace) || name != strings.ToLower(name) {
+			return fmt.Errorf("text command %q: name %q must be lowercase without spaces", c.Name, name)
+		}
+	}
+
+	if c.Handler == nil {
+		return fmt.Errorf("text command %q has no handler", c.Name)
+	}
+
+	optional := false
+	for i, arg := range c.Args {
+
+		if arg.Rest && i != len(c.Args)-1 {
+			return fmt.Errorf("text command %q: argument %q takes the rest of the message, so it must be last", c.Name, arg.Name)
+		}
+
+		if arg.Required && optional {
+			return fmt.Errorf("text command %q: required argument %q follows an optional argument", c.Name, arg.Name)
+		}
+		optional = optional || !arg.Required
+	}
+
+	return nil
+}
@@ -0,0 +1,183 @@
+package textcommands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"brandenly.com/go/packages/discord-bot/common"
+	"brandenly.com/go/packages/discord-bot/cooldown"
+	"brandenly.com/go/packages/discord-bot/discord"
+	"brandenly.com/go/packages/discord-bot/gateway"
+)
+
+// A message invoking a text command.
+type Context struct {
+	context.Context
+	App     *discord.App           // Application that received the message
+	Message *gateway.MessageCreate // The message invoking the command
+	Command *Command               // The invoked command
+	Prefix  string                 // Prefix the command was invoked with, such as "!" or the bot's mention
+	Name    string                 // Name or alias the command was invoked by
+	Args    []string               // Arguments of the command, split by Tokenize
+	Raw     string                 // Text following the command name, as sent
+
+	tokens      []token
+	usagePrefix string // Prefix shown in usage, which is readable even when the command was invoked by mention
+}
+
+// Reports that a command was invoked with arguments it can't use. Rather than passing it on as a
+// handler error, the router replies with the error and the command's usage.
+type UsageError struct {
+	Err error
+}
+
+func (e *UsageError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *UsageError) Unwrap() error {
+	return e.Err
+}
+
+// Returns a UsageError with a formatted message.
+func (c *Context) UsageError(format string, args ...any) error {
+	return &UsageError{Err: fmt.Errorf(format, args...)}
+}
+
+// Returns how the command is invoked, such as "!ban <user> [reason...]".
+func (c *Context) Usage() string {
+	return c.Command.UsageLine(c.usagePrefix)
+}
+
+//// Arguments
+
+// Returns the argument at index, or "" if there are fewer arguments.
+func (c *Context) Arg(index int) string {
+
+	if index < 0 || index >= len(c.Args) {
+		return ""
+	}
+
+	return c.Args[index]
+}
+
+// Returns the text from the argument at index to the end of the message as sent, with quotes and
+// spacing intact, or "" if there are fewer arguments. A single remaining quoted argument is returned
+// without its quotes.
+func (c *Context) Rest(index int) string {
+
+	if index < 0 || index >= len(c.tokens) {
+		return ""
+	}
+
+	if index == len(c.tokens)-1 {
+		return c.tokens[index].value
+	}
+
+	return strings.TrimSpace(c.Raw[c.tokens[index].start:])
+}
+
+//// Invocation
+
+// Returns the author of the message.
+func (c *Context) Author() common.User {
+	return c.Message.Author
+}
+
+// Returns the author's guild member, with its user set, or false in DMs.
+func (c *Context) Member() (common.Member, bool) {
+
+	if c.Message.Member == nil {
+		return common.Member{}, false
+	}
+
+	member := *c.Message.Member
+	member.User = &c.Message.Author
+
+	return member, true
+}
+
+// Returns the ID of the guild the message was sent in, or zero in DMs.
+func (c *Context) GuildId() common.Snowflake {
+
+	if c.Message.GuildId == nil {
+		return 0
+	}
+
+	return *c.Message.GuildId
+}
+
+// Returns the ID of the channel the message was sent in.
+func (c *Context) ChannelId() common.Snowflake {
+	return c.Message.ChannelId
+}
+
+// Returns who invoked the command and where, as counted by cooldowns.
+func (c *Context) Invocation() cooldown.Invocation {
+
+	invocation := cooldown.Invocation{UserId: c.Message.Author.Id, GuildId: c.GuildId(), ChannelId: c.ChannelId()}
+	if c.Message.Member != nil {
+		invocation.Roles = c.Message.Member.Roles
+	}
+
+	return invocation
+}
+
+//// Responses
+
+// Sends a message to the channel the command was invoked in.
+func (c *Context) Respond(params common.MessageParams) (common.Message, error) {
+	return c.App.CreateMessage(c.Message.ChannelId, params)
+}
+
+// Sends content to the channel the command was invoked in. Mentions in content don't notify anyone.
+func (c *Context) Send(content string) (common.Message, error) {
+	return c.Respond(common.MessageParams{Content: &content, AllowedMentions: &common.AllowedMention{Parse: &[]string{}}})
+}
+
+// Replies to the invoking message with content. Mentions in content don't notify anyone, including
+// the author.
+func (c *Context) Reply(content string) (common.Message, error) {
+
+	failIfNotExist := false
+
+	return c.Respond(common.MessageParams{
+		Content:         &content,
+		AllowedMentions: &common.AllowedMention{Parse: &[]string{}},
+		MessageReference: &common.MessageReference{
+			MessageId:      &c.Message.Id,
+			ChannelId:      &c.Message.ChannelId,
+			GuildId:        c.Message.GuildId,
+			FailIfNotExist: &failIfNotExist,
+		},
+	})
+}
+
+// Replies with err and the command's usage.
+func (c *Context) replyUsage(err error) error {
+
+	message := fmt.Sprintf("%s\nUsage: `%s`", capitalize(err.Error()), c.Usage())
+	if utf8.RuneCountInString(message) > discord.MaxMessageLength {
+		message = fmt.Sprintf("Invalid arguments.\nUsage: `%s`", c.Usage())
+	}
+
+	if _, replyErr := c.Reply(message); replyErr != nil {
+		return errors.Join(err, replyErr)
+	}
+
+	return nil
+}
+
+func capitalize(text string) string {
+
+	if text == "" {
+		return text
+	}
+
+	first, size := utf8.DecodeRuneInString(text)
+	return string(unicode.ToUpper(first)) + text[size:]
+}
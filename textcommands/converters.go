@@ -0,0 +1,375 @@
+package textcommands
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"brandenly.com/go/packages/discord-bot/common"
+)
+
+// Converts an argument to a value of the type it was registered for.
+type Converter func(ctx *Context, arg string) (any, error)
+
+var (
+	convertersMu sync.RWMutex
+	converters   map[reflect.Type]Converter = map[reflect.Type]Converter{
+		reflect.TypeFor[common.User]():      func(ctx *Context, arg string) (any, error) { return ctx.User(arg) },
+		reflect.TypeFor[common.Member]():    func(ctx *Context, arg string) (any, error) { return ctx.MemberOf(arg) },
+		reflect.TypeFor[common.Role]():      func(ctx *Context, arg string) (any, error) { return ctx.Role(arg) },
+		reflect.TypeFor[common.Channel]():   func(ctx *Context, arg string) (any, error) { return ctx.Channel(arg) },
+		reflect.TypeFor[common.Snowflake](): func(ctx *Context, arg string) (any, error) { return parseId(arg) },
+		reflect.TypeFor[time.Duration]():    func(ctx *Context, arg string) (any, error) { return parseDuration(arg) },
+	}
+)
+
+// Registers the converter used for arguments of type T, replacing any earlier one.
+func RegisterConverter[T any](convert func(ctx *Context, arg string) (T, error)) {
+
+	convertersMu.Lock()
+	defer convertersMu.Unlock()
+
+	converters[reflect.TypeFor[T]()] = func(ctx *Context, arg string) (any, error) {
+		return convert(ctx, arg)
+	}
+}
+
+// Reports whether arguments can be converted to values of type t.
+func convertible(t reflect.Type) bool {
+
+	convertersMu.RLock()
+	_, ok := converters[t]
+	convertersMu.RUnlock()
+
+	switch t.Kind() {
+	case reflect.String, reflect.Bool, reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+
+	return ok
+}
+
+// Converts arg to a value of type t, using a registered converter or parsing it by t's kind.
+func (c *Context) convert(arg string, t reflect.Type) (reflect.Value, error) {
+
+	convertersMu.RLock()
+	convert, ok := converters[t]
+	convertersMu.RUnlock()
+
+	if ok {
+		converted, err := convert(c, arg)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		// A converter for an interface type can return nil
+		result := reflect.ValueOf(converted)
+		if !result.IsValid() {
+			return reflect.Value{}, fmt.Errorf("%q is not a valid %s", arg, t)
+		}
+
+		return result.Convert(t), nil
+	}
+
+	value := reflect.New(t).Elem()
+
+	switch t.Kind() {
+
+	case reflect.String:
+		value.SetString(arg)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil || value.OverflowInt(n) {
+			return reflect.Value{}, fmt.Errorf("%q is not a whole number", arg)
+		}
+		value.SetInt(n)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(arg, 10, 64)
+		if err != nil || value.OverflowUint(n) {
+			return reflect.Value{}, fmt.Errorf("%q is not a positive whole number", arg)
+		}
+		value.SetUint(n)
+
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("%q is not a number", arg)
+		}
+		value.SetFloat(n)
+
+	case reflect.Bool:
+		switch strings.ToLower(arg) {
+		case "true", "yes", "y", "on", "1":
+			value.SetBool(true)
+		case "false", "no", "n", "off", "0":
+			value.SetBool(false)
+		default:
+			return reflect.Value{}, fmt.Errorf("%q is not yes or no", arg)
+		}
+
+	default:
+		return reflect.Value{}, fmt.Errorf("no converter for %s", t)
+	}
+
+	return value, nil
+}
+
+//// Users
+
+// Converts a user mention, ID, username, display name or nickname to a user. Names are matched
+// without regard to case and require the application's state cache. Users that aren't mentioned or
+// cached are returned with only their ID set.
+func (c *Context) User(arg string) (common.User, error) {
+
+	id, ok := mentionId(arg, "<@!", "<@")
+	if !ok {
+		var err error
+		if id, err = parseId(arg); err != nil {
+
+			member, err := c.memberByName(arg)
+			if err != nil {
+				return common.User{}, err
+			}
+
+			return *member.User, nil
+		}
+	}
+
+	return c.userById(id), nil
+}
+
+// Converts a mention, ID, username, display name or nickname of a member of the guild the command
+// was invoked in to a member, with its user set. Requires the application's state cache for members
+// other than the author.
+func (c *Context) MemberOf(arg string) (common.Member, error) {
+
+	if c.Message.GuildId == nil {
+		return common.Member{}, fmt.Errorf("members can only be found in servers")
+	}
+
+	id, ok := mentionId(arg, "<@!", "<@")
+	if !ok {
+		var err error
+		if id, err = parseId(arg); err != nil {
+			return c.memberByName(arg)
+		}
+	}
+
+	if member, ok := c.Member(); ok && id == c.Message.Author.Id {
+		return member, nil
+	}
+
+	if c.App.State != nil {
+		if member, ok := c.App.State.GetMember(*c.Message.GuildId, id); ok && member.User != nil {
+			return member, nil
+		}
+	}
+
+	return common.Member{}, fmt.Errorf("member %s was not found", arg)
+}
+
+func (c *Context) userById(id common.Snowflake) common.User {
+
+	if id == c.Message.Author.Id {
+		return c.Message.Author
+	}
+
+	for _, user := range c.Message.Mentions {
+		if user.Id == id {
+			return user
+		}
+	}
+
+	if c.App.State != nil && c.Message.GuildId != nil {
+		if member, ok := c.App.State.GetMember(*c.Message.GuildId, id); ok && member.User != nil {
+			return *member.User
+		}
+	}
+
+	return common.User{Id: id}
+}
+
+func (c *Context) memberByName(name string) (common.Member, error) {
+
+	if c.App.State == nil || c.Message.GuildId == nil {
+		return common.Member{}, fmt.Errorf("user %q was not found", name)
+	}
+
+	return findByName("user", name, c.App.State.GuildMembers(*c.Message.GuildId), func(member common.Member) []string {
+
+		if member.User == nil {
+			return nil
+		}
+
+		names := []string{member.User.Username, member.User.Username + "#" + member.User.Discriminator}
+		if member.User.GlobalName != nil {
+			names = append(names, *member.User.GlobalName)
+		}
+		if member.Nick != nil {
+			names = append(names, *member.Nick)
+		}
+
+		return names
+	})
+}
+
+//// Roles and Channels
+
+// Converts a role mention, ID or name to a role of the guild the command was invoked in. Names are
+// matched without regard to case and require the application's state cache. Roles that aren't cached
+// are returned with only their ID set.
+func (c *Context) Role(arg string) (common.Role, error) {
+
+	if c.Message.GuildId == nil {
+		return common.Role{}, fmt.Errorf("roles can only be found in servers")
+	}
+
+	id, ok := mentionId(arg, "<@&")
+	if !ok {
+		var err error
+		if id, err = parseId(arg); err != nil {
+			return c.roleByName(strings.TrimPrefix(arg, "@"))
+		}
+	}
+
+	if c.App.State != nil {
+		if role, ok := c.App.State.GetRole(*c.Message.GuildId, id); ok {
+			return role, nil
+		}
+	}
+
+	return common.Role{Id: id}, nil
+}
+
+func (c *Context) roleByName(name string) (common.Role, error) {
+
+	if c.App.State == nil {
+		return common.Role{}, fmt.Errorf("role %q was not found", name)
+	}
+
+	return findByName("role", name, c.App.State.GuildRoles(*c.Message.GuildId), func(role common.Role) []string {
+		return []string{role.Name}
+	})
+}
+
+// Converts a channel mention, ID or name to a channel. Names, with or without a leading "#", are
+// matched without regard to case against the channels and threads of the guild the command was
+// invoked in, and require the application's state cache. Channels that aren't cached are returned
+// with only their ID set.
+func (c *Context) Channel(arg string) (common.Channel, error) {
+
+	id, ok := mentionId(arg, "<#")
+	if !ok {
+		var err error
+		if id, err = parseId(arg); err != nil {
+			return c.channelByName(strings.TrimPrefix(arg, "#"))
+		}
+	}
+
+	if c.App.State != nil {
+		if channel, ok := c.App.State.GetChannel(id); ok {
+			return channel, nil
+		}
+	}
+
+	return common.Channel{Id: id}, nil
+}
+
+func (c *Context) channelByName(name string) (common.Channel, error) {
+
+	if c.App.State == nil || c.Message.GuildId == nil {
+		return common.Channel{}, fmt.Errorf("channel %q was not found", name)
+	}
+
+	channels := append(c.App.State.GuildChannels(*c.Message.GuildId), c.App.State.GuildThreads(*c.Message.GuildId)...)
+
+	return findByName("channel", name, channels, func(channel common.Channel) []string {
+
+		if channel.Name == nil {
+			return nil
+		}
+
+		return []string{*channel.Name}
+	})
+}
+
+//// Parsing
+
+// Returns the single candidate one of whose names matches name without regard to case.
+func findByName[T any](kind string, name string, candidates []T, names func(T) []string) (T, error) {
+
+	var found []T
+	for _, candidate := range candidates {
+		for _, candidateName := range names(candidate) {
+			if strings.EqualFold(candidateName, name) {
+				found = append(found, candidate)
+				break
+			}
+		}
+	}
+
+	var none T
+	switch len(found) {
+	case 0:
+		return none, fmt.Errorf("%s %q was not found", kind, name)
+	case 1:
+		return found[0], nil
+	}
+
+	return none, fmt.Errorf("%d %ss are named %q; use a mention or ID instead", len(found), kind, name)
+}
+
+// Returns the ID in a mention such as <@123> when arg starts with one of prefixes.
+func mentionId(arg string, prefixes ...string) (common.Snowflake, bool) {
+
+	if !strings.HasSuffix(arg, ">") {
+		return 0, false
+	}
+
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(arg, prefix) {
+			id, err := common.ParseSnowflake(arg[len(prefix) : len(arg)-1])
+			return id, err == nil
+		}
+	}
+
+	return 0, false
+}
+
+func parseId(arg string) (common.Snowflake, error) {
+
+	id, err := common.ParseSnowflake(arg)
+	if err != nil || id == 0 {
+		return 0, fmt.Errorf("%q is not an ID", arg)
+	}
+
+	return id, nil
+}
+
+// Parses a duration such as "90s", "1h30m" or "2d"; a bare number is read as seconds.
+func parseDuration(arg string) (time.Duration, error) {
+
+	if seconds, err := strconv.ParseFloat(arg, 64); err == nil {
+		return time.Duration(seconds * float64(time.Second)), nil
+	}
+
+	if days, ok := strings.CutSuffix(arg, "d"); ok {
+		if n, err := strconv.ParseFloat(days, 64); err == nil {
+			return time.Duration(n * float64(24*time.Hour)), nil
+		}
+	}
+
+	duration, err := time.ParseDuration(arg)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a duration such as 90s or 1h30m", arg)
+	}
+
+	return duration, nil
+}
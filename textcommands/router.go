@@ -0,0 +1,302 @@
+package textcommands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+
+	"brandenly.com/go/packages/discord-bot/common"
+	"brandenly.com/go/packages/discord-bot/cooldown"
+	"brandenly.com/go/packages/discord-bot/discord"
+	"brandenly.com/go/packages/discord-bot/gateway"
+)
+
+// Returns the prefixes of a guild, or of DMs when guildId is zero. Returning none falls back to the
+// router's prefixes.
+type PrefixFunc func(guildId common.Snowflake) []string
+
+// Routes messages starting with a prefix to text commands by name. Without the MESSAGE_CONTENT
+// intent, Discord only sends the content of DMs and of messages that mention the bot, so commands
+// keep working in those, and always by mention, while other messages are ignored.
+type Router struct {
+	MentionPrefix bool       // Whether mentioning the bot, as in "@Bot ping", invokes commands too; set by NewRouter
+	AllowBots     bool       // Whether messages from bots and webhooks can invoke commands
+	Prefixes      PrefixFunc // Optional; returns the prefixes of a guild, such as from a database, in place of SetPrefixes
+
+	mu       sync.RWMutex
+	commands []*Command
+	names    map[string]*Command
+	defaults []string
+	guilds   map[common.Snowflake][]string
+
+	warnContent sync.Once
+}
+
+// Returns a router invoking commands by the given prefixes, such as "!", and by mention.
+func NewRouter(prefixes ...string) *Router {
+	return &Router{
+		MentionPrefix: true,
+		names:         map[string]*Command{},
+		defaults:      prefixes,
+		guilds:        map[common.Snowflake][]string{},
+	}
+}
+
+// Adds commands to the router, validating each first. A command replaces an earlier one of the same
+// name, but a name or alias can't be claimed by two commands.
+func (r *Router) Add(commands ...*Command) error {
+
+	for _, command := range commands {
+		if err := command.Validate(); err != nil {
+			return err
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.names == nil {
+		r.names = map[string]*Command{}
+	}
+
+	for _, command := range commands {
+
+		replaced, ok := r.names[command.Name]
+		if ok && replaced.Name != command.Name {
+			replaced = nil
+		}
+
+		for _, name := range command.Names() {
+			if existing, ok := r.names[name]; ok && existing != replaced {
+				return fmt.Errorf("text command %q: %q is already taken by %q", command.Name, name, existing.Name)
+			}
+		}
+
+		if replaced != nil {
+			r.remove(replaced)
+		}
+
+		r.commands = append(r.commands, command)
+		for _, name := range command.Names() {
+			r.names[name] = command
+		}
+	}
+
+	return nil
+}
+
+func (r *Router) remove(command *Command) {
+
+	r.commands = slices.DeleteFunc(r.commands, func(c *Command) bool { return c == command })
+	for _, name := range command.Names() {
+		delete(r.names, name)
+	}
+}
+
+// Returns the command invoked by a name or alias.
+func (r *Router) Command(name string) (*Command, bool) {
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	command, ok := r.names[strings.ToLower(name)]
+	return command, ok
+}
+
+// Returns every command in the router, in the order they were added.
+func (r *Router) Commands() []*Command {
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return slices.Clone(r.commands)
+}
+
+//// Prefixes
+
+// Sets the prefixes of a guild, replacing the router's prefixes there. Setting none restores them.
+func (r *Router) SetPrefixes(guildId common.Snowflake, prefixes ...string) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(prefixes) == 0 {
+		delete(r.guilds, guildId)
+		return
+	}
+
+	if r.guilds == nil {
+		r.guilds = map[common.Snowflake][]string{}
+	}
+
+	r.guilds[guildId] = slices.Clone(prefixes)
+}
+
+// Returns a copy of the prefixes commands are invoked by in a guild, or in DMs when guildId is zero,
+// not counting mentions.
+func (r *Router) PrefixesOf(guildId common.Snowflake) []string {
+
+	if r.Prefixes != nil {
+		if prefixes := r.Prefixes(guildId); len(prefixes) > 0 {
+			return slices.Clone(prefixes)
+		}
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if prefixes, ok := r.guilds[guildId]; ok && guildId != 0 {
+		return slices.Clone(prefixes)
+	}
+
+	return slices.Clone(r.defaults)
+}
+
+// Returns the prefix content starts with, and whether it is a mention of the bot. Longer prefixes
+// are tried first, so that "!!" wins over "!".
+func (r *Router) matchPrefix(a *discord.App, guildId common.Snowflake, content string, mentionOnly bool) (string, bool, bool) {
+
+	if r.MentionPrefix || mentionOnly {
+		for _, mention := range []string{fmt.Sprintf("<@%s>", a.Id), fmt.Sprintf("<@!%s>", a.Id)} {
+			if strings.HasPrefix(content, mention) {
+				return mention, true, true
+			}
+		}
+	}
+
+	if mentionOnly {
+		return "", false, false
+	}
+
+	prefixes := r.PrefixesOf(guildId)
+	slices.SortStableFunc(prefixes, func(a, b string) int { return len(b) - len(a) })
+
+	for _, prefix := range prefixes {
+		if prefix != "" && strings.HasPrefix(content, prefix) {
+			return prefix, false, true
+		}
+	}
+
+	return "", false, false
+}
+
+//// Handling
+
+// Subscribes the router to the application's MESSAGE_CREATE events.
+func (r *Router) Register(a *discord.App) (unsubscribe func()) {
+	return discord.On(a, r.Handle)
+}
+
+// Invokes the command a message starts with. Messages without a prefix or with an unknown command
+// are ignored, so that several routers can share an application. Arguments that can't be tokenized,
+// and UsageErrors returned by handlers, are replied to with the command's usage.
+func (r *Router) Handle(ctx context.Context, a *discord.App, message *gateway.MessageCreate) error {
+
+	if !r.AllowBots && message.Author.Bot != nil && *message.Author.Bot {
+		return nil
+	}
+
+	guildId := common.Snowflake(0)
+	if message.GuildId != nil {
+		guildId = *message.GuildId
+	}
+
+	// Without the MESSAGE_CONTENT intent, guild messages only have content when they mention the bot
+	mentionOnly := false
+	if intents := a.Intents(); intents != 0 && !intents.Has(gateway.IntentMessageContent) && guildId != 0 {
+		mentionOnly = true
+		r.warnContent.Do(func() {
+			a.Logger.Printf("Warning: text commands can only be invoked in DMs and by mentioning the bot; request the MESSAGE_CONTENT intent to invoke them by prefix in guilds")
+		})
+	}
+
+	prefix, mention, ok := r.matchPrefix(a, guildId, message.Content, mentionOnly)
+	if !ok {
+		return nil
+	}
+
+	input := message.Content[len(prefix):]
+	if mention {
+		input = strings.TrimLeftFunc(input, unicode.IsSpace)
+	}
+
+	name, raw := input, ""
+	if end := strings.IndexFunc(input, unicode.IsSpace); end >= 0 {
+		name, raw = input[:end], input[end:]
+	}
+
+	command, ok := r.Command(name)
+	if !ok {
+		return nil
+	}
+
+	c := &Context{
+		Context:     ctx,
+		App:         a,
+		Message:     message,
+		Command:     command,
+		Prefix:      prefix,
+		Name:        strings.ToLower(name),
+		Raw:         raw,
+		usagePrefix: prefix,
+	}
+
+	if mention {
+		c.usagePrefix = "@" + botName(a) + " "
+		if prefixes := r.PrefixesOf(guildId); len(prefixes) > 0 && !mentionOnly {
+			c.usagePrefix = prefixes[0]
+		}
+	}
+
+	tokens, err := tokenize(raw)
+	if err != nil {
+		return c.replyUsage(err)
+	}
+
+	c.tokens = tokens
+	c.Args = make([]string, len(tokens))
+	for i, token := range tokens {
+		c.Args[i] = token.value
+	}
+
+	release, err := command.Limits.Acquire(c.Invocation())
+	if err != nil {
+		var limited *cooldown.Error
+		if errors.As(err, &limited) {
+			_, err = c.Reply(limited.Message)
+		}
+		return err
+	}
+	defer release()
+
+	err = command.Handler(c)
+
+	var usage *UsageError
+	if errors.As(err, &usage) {
+		return c.replyUsage(usage)
+	}
+
+	if err != nil {
+		return fmt.Errorf("text command %q: %w", command.Name, err)
+	}
+
+	return nil
+}
+
+// Returns the name the bot is mentioned by.
+func botName(a *discord.App) string {
+
+	if a.Bot.Username != "" {
+		return a.Bot.Username
+	}
+
+	if a.Name != "" {
+		return a.Name
+	}
+
+	return "bot"
+}
@@ -0,0 +1,130 @@
+package textcommands
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Returned for arguments with a quote that is never closed.
+var ErrUnterminatedQuote = errors.New("unterminated quote")
+
+// Returned for arguments with a code block that is never closed.
+var ErrUnterminatedCodeBlock = errors.New("unterminated code block")
+
+// Closing quote of each quote that can group an argument. Curly quotes are included for mobile
+// keyboards that insert them automatically.
+var quotes map[rune]rune = map[rune]rune{
+	'"':  '"',
+	'\'': '\'',
+	'`':  '`',
+	'“':  '”',
+	'‘':  '’',
+}
+
+// An argument and where it was found in the input.
+type token struct {
+	value string
+	start int // Byte offset of the argument in the input, including any opening quote
+	end   int // Byte offset just past the argument, including any closing quote
+}
+
+// Splits input into arguments the way a shell would. Arguments are separated by whitespace, except
+// that an argument starting with a quote runs to the matching closing quote, and a code block
+// fenced by ``` is a single argument whose language tag is dropped. Within double quotes a
+// backslash escapes the next character. Quotes inside an argument, as in "don't", are kept as is.
+func Tokenize(input string) ([]string, error) {
+
+	tokens, err := tokenize(input)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]string, len(tokens))
+	for i, token := range tokens {
+		values[i] = token.value
+	}
+
+	return values, nil
+}
+
+func tokenize(input string) ([]token, error) {
+
+	var tokens []token
+	i := 0
+
+	for {
+
+		for i < len(input) {
+			r, size := utf8.DecodeRuneInString(input[i:])
+			if !unicode.IsSpace(r) {
+				break
+			}
+			i += size
+		}
+
+		if i >= len(input) {
+			return tokens, nil
+		}
+
+		start := i
+		first, size := utf8.DecodeRuneInString(input[i:])
+
+		switch closing, quoted := quotes[first]; {
+
+		case strings.HasPrefix(input[i:], "```"):
+
+			end := strings.Index(input[i+3:], "```")
+			if end < 0 {
+				return nil, ErrUnterminatedCodeBlock
+			}
+
+			body := input[i+3 : i+3+end]
+			if line, rest, found := strings.Cut(body, "\n"); found && !strings.ContainsFunc(line, unicode.IsSpace) {
+				body = rest // Language tag, as in ```go
+			}
+
+			i += 3 + end + 3
+			tokens = append(tokens, token{value: strings.Trim(body, "\n"), start: start, end: i})
+
+		case quoted:
+
+			var value strings.Builder
+			i += size
+
+			for {
+
+				if i >= len(input) {
+					return nil, ErrUnterminatedQuote
+				}
+
+				r, size := utf8.DecodeRuneInString(input[i:])
+				i += size
+
+				if r == closing {
+					break
+				}
+
+				if r == '\\' && first == '"' && i < len(input) {
+					r, size = utf8.DecodeRuneInString(input[i:])
+					i += size
+				}
+
+				value.WriteRune(r)
+			}
+
+			tokens = append(tokens, token{value: value.String(), start: start, end: i})
+
+		default:
+
+			end := strings.IndexFunc(input[i:], unicode.IsSpace)
+			if end < 0 {
+				end = len(input) - i
+			}
+
+			i += end
+			tokens = append(tokens, token{value: input[start:i], start: start, end: i})
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package textcommands
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+		err   error
+	}{
+		{
+			name:  "empty",
+			input: "   ",
+			want:  nil,
+		},
+		{
+			name:  "whitespace separated",
+			input: " ban  @user\tspamming\nagain ",
+			want:  []string{"ban", "@user", "spamming", "again"},
+		},
+		{
+			name:  "double quotes",
+			input: `say "hello world" now`,
+			want:  []string{"say", "hello world", "now"},
+		},
+		{
+			name:  "single quotes",
+			input: `say 'hello world'`,
+			want:  []string{"say", "hello world"},
+		},
+		{
+			name:  "empty quotes",
+			input: `a "" b`,
+			want:  []string{"a", "", "b"},
+		},
+		{
+			name:  "escapes in double quotes",
+			input: `"say \"hi\" \\ there"`,
+			want:  []string{`say "hi" \ there`},
+		},
+		{
+			name:  "no escapes in single quotes",
+			input: `'a\b'`,
+			want:  []string{`a\b`},
+		},
+		{
+			name:  "quotes inside a word are kept",
+			input: `don't "stop"`,
+			want:  []string{"don't", "stop"},
+		},
+		{
+			name:  "curly double quotes",
+			input: "say “hello world”",
+			want:  []string{"say", "hello world"},
+		},
+		{
+			name:  "curly single quotes",
+			input: "say ‘hello world’",
+			want:  []string{"say", "hello world"},
+		},
+		{
+			name:  "curly quote closes only with its pair",
+			input: "“a \"b\" c”",
+			want:  []string{`a "b" c`},
+		},
+		{
+			name:  "inline code",
+			input: "run `x := 1` now",
+			want:  []string{"run", "x := 1", "now"},
+		},
+		{
+			name:  "code block drops its language tag",
+			input: "eval ```go\nfmt.Println(\"hi\")\n``` after",
+			want:  []string{"eval", `fmt.Println("hi")`, "after"},
+		},
+		{
+			name:  "code block without a language tag",
+			input: "eval ```\nx = 1\ny = 2\n```",
+			want:  []string{"eval", "x = 1\ny = 2"},
+		},
+		{
+			name:  "single line code block keeps its text",
+			input: "eval ```x + y```",
+			want:  []string{"eval", "x + y"},
+		},
+		{
+			name:  "code block whose first line has spaces keeps it",
+			input: "eval ```a b\nc```",
+			want:  []string{"eval", "a b\nc"},
+		},
+		{
+			name:  "unterminated double quote",
+			input: `say "hello`,
+			err:   ErrUnterminatedQuote,
+		},
+		{
+			name:  "unterminated curly quote",
+			input: "say “hello\"",
+			err:   ErrUnterminatedQuote,
+		},
+		{
+			name:  "escaped closing quote leaves the quote open",
+			input: `"hello\"`,
+			err:   ErrUnterminatedQuote,
+		},
+		{
+			name:  "unterminated code block",
+			input: "eval ```go\nx",
+			err:   ErrUnterminatedCodeBlock,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			got, err := Tokenize(test.input)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("Tokenize(%q) error = %v, want %v", test.input, err, test.err)
+			}
+
+			if !slices.Equal(got, test.want) {
+				t.Errorf("Tokenize(%q) = %q, want %q", test.input, got, test.want)
+			}
+		})
+	}
+}
+
+func TestTokenizeOffsets(t *testing.T) {
+
+	input := `kick  "some user"  for ‘being rude’`
+
+	tokens, err := tokenize(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"kick", `"some user"`, "for", "‘being rude’"}
+	if len(tokens) != len(want) {
+		t.Fatalf("got %d tokens, want %d", len(tokens), len(want))
+	}
+
+	for i, token := range tokens {
+		if got := input[token.start:token.end]; got != want[i] {
+			t.Errorf("token %d spans %q, want %q", i, got, want[i])
+		}
+	}
+}
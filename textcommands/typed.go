@@ -0,0 +1,235 @@
+package textcommands
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Struct tag key declaring command arguments.
+const ArgTag = "discord"
+
+// An argument declared by a struct field.
+type argField struct {
+	index []int
+	arg   Arg
+}
+
+var argFieldCache sync.Map // reflect.Type -> []argField
+
+// Returns the arguments declared by the fields of struct T, in field order. Each field tagged
+// `discord:"..."` declares an argument; the tag lists the argument name followed by comma separated
+// settings:
+//
+//	required    The command fails without the argument
+//	rest        The argument takes the rest of the message as sent; must be the last, and a string
+//	desc=...    Description of the argument; must be the last setting, and may contain commas
+//
+// An empty name uses the lowercased field name. Fields are typed as strings, integers, floats, bools,
+// time.Duration, common.Snowflake, common.User, common.Member, common.Role, common.Channel or a type
+// with a registered converter, or pointers to these that are left nil when the argument is missing.
+// An optional pointer field that can't convert its argument is also left nil, and the argument is
+// tried with the next field, so "!ban @user spamming" works with an optional duration before the
+// reason. A slice field, which must be the last, takes each remaining argument.
+func ArgsOf[T any]() ([]Arg, error) {
+
+	fields, err := argFieldsOf(reflect.TypeFor[T]())
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]Arg, 0, len(fields))
+	for _, field := range fields {
+		args = append(args, field.arg)
+	}
+
+	return args, nil
+}
+
+// Returns a command whose arguments are declared by the fields of T (see ArgsOf), and whose handler
+// receives them converted. Arguments that are missing or can't be converted are replied to with the
+// command's usage. Panics if T's tags are invalid.
+func Typed[T any](name string, description string, handler func(ctx *Context, args *T) error) *Command {
+
+	args, err := ArgsOf[T]()
+	if err != nil {
+		panic(fmt.Errorf("text command %q: %w", name, err))
+	}
+
+	return &Command{
+		Name:        name,
+		Description: description,
+		Args:        args,
+		Handler: func(ctx *Context) error {
+
+			var values T
+			if err := ctx.Decode(&values); err != nil {
+				return err
+			}
+
+			return handler(ctx, &values)
+		},
+	}
+}
+
+// Converts the command's arguments into the tagged fields of the struct dst points to. See ArgsOf.
+// Arguments that are missing or can't be converted are reported as a UsageError.
+func (c *Context) Decode(dst any) error {
+
+	target := reflect.ValueOf(dst)
+	if target.Kind() != reflect.Pointer || target.IsNil() || target.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("decode target must be a non-nil pointer to a struct, got %T", dst)
+	}
+
+	fields, err := argFieldsOf(target.Elem().Type())
+	if err != nil {
+		return err
+	}
+
+	position := 0
+	for i, field := range fields {
+
+		value := target.Elem().FieldByIndex(field.index)
+
+		if position >= len(c.Args) {
+			if field.arg.Required {
+				return c.UsageError("%s is required", field.arg.Name)
+			}
+			continue
+		}
+
+		switch {
+
+		case value.Kind() == reflect.Slice:
+			for ; position < len(c.Args); position++ {
+				converted, err := c.convert(c.Args[position], value.Type().Elem())
+				if err != nil {
+					return &UsageError{Err: fmt.Errorf("%s: %w", field.arg.Name, err)}
+				}
+				value.Set(reflect.Append(value, converted))
+			}
+
+		case field.arg.Rest:
+			value.SetString(c.Rest(position))
+			position = len(c.Args)
+
+		default:
+
+			t := value.Type()
+			if value.Kind() == reflect.Pointer {
+				t = t.Elem()
+			}
+
+			converted, err := c.convert(c.Args[position], t)
+			if err != nil && value.Kind() == reflect.Pointer && !field.arg.Required && i < len(fields)-1 {
+				continue // Skipped optional argument; the next one may accept it
+			}
+			if err != nil {
+				return &UsageError{Err: fmt.Errorf("%s: %w", field.arg.Name, err)}
+			}
+
+			if value.Kind() == reflect.Pointer {
+				value.Set(reflect.New(t))
+				value = value.Elem()
+			}
+
+			value.Set(converted)
+			position++
+		}
+	}
+
+	return nil
+}
+
+//// Tags
+
+func argFieldsOf(t reflect.Type) ([]argField, error) {
+
+	if cached, ok := argFieldCache.Load(t); ok {
+		return cached.([]argField), nil
+	}
+
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("arguments must be declared by a struct, got %s", t)
+	}
+
+	var fields []argField
+	for _, field := range reflect.VisibleFields(t) {
+
+		tag, ok := field.Tag.Lookup(ArgTag)
+		if !ok || tag == "-" || !field.IsExported() {
+			continue
+		}
+
+		if len(fields) > 0 {
+			last := fields[len(fields)-1]
+			if last.arg.Rest || t.FieldByIndex(last.index).Type.Kind() == reflect.Slice {
+				return nil, fmt.Errorf("field %s.%s: argument %q takes the remaining arguments, so it must be last", t.Name(), field.Name, last.arg.Name)
+			}
+		}
+
+		arg, err := parseArgTag(field, tag)
+		if err != nil {
+			return nil, fmt.Errorf("field %s.%s: %w", t.Name(), field.Name, err)
+		}
+
+		fields = append(fields, argField{index: field.Index, arg: arg})
+	}
+
+	argFieldCache.Store(t, fields)
+	return fields, nil
+}
+
+func parseArgTag(field reflect.StructField, tag string) (Arg, error) {
+
+	name, settings, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = strings.ToLower(field.Name)
+	}
+
+	fieldType := field.Type
+	if fieldType.Kind() == reflect.Pointer || fieldType.Kind() == reflect.Slice {
+		fieldType = fieldType.Elem()
+	}
+
+	if !convertible(fieldType) {
+		return Arg{}, fmt.Errorf("unsupported argument field type %s", field.Type)
+	}
+
+	arg := Arg{Name: name, Rest: field.Type.Kind() == reflect.Slice}
+
+	for settings != "" {
+
+		var setting string
+		if strings.HasPrefix(settings, "desc=") {
+			setting, settings = settings, ""
+		} else {
+			setting, settings, _ = strings.Cut(settings, ",")
+		}
+
+		key, value, _ := strings.Cut(strings.TrimSpace(setting), "=")
+
+		switch key {
+
+		case "required":
+			arg.Required = true
+
+		case "rest":
+			if field.Type.Kind() != reflect.String {
+				return Arg{}, fmt.Errorf("only string fields can take the rest of the message")
+			}
+			arg.Rest = true
+
+		case "desc":
+			arg.Description = value
+
+		case "":
+
+		default:
+			return Arg{}, fmt.Errorf("unknown tag setting %q", key)
+		}
+	}
+
+	return arg, nil
+}
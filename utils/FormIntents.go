@@ -1,9 +1,12 @@
 package utils
 
-func FormIntents(numbers ...int) int {
-	total := 0
-	for _, num := range numbers {
-		total += num
+import "brandenly.com/go/packages/discord-bot/gateway"
+
+// Combines intents into a single set. Passing an intent more than once has no additional effect.
+func FormIntents(intents ...gateway.Intents) gateway.Intents {
+	var total gateway.Intents
+	for _, intent := range intents {
+		total |= intent
 	}
 	return total
 }
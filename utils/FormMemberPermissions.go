@@ -1,9 +1,12 @@
 package utils
 
-func FormMemberPermissions(numbers ...int) int {
-	total := 0
-	for _, num := range numbers {
-		total += num
+import "brandenly.com/go/packages/discord-bot/common"
+
+// Combines permissions into a single set. Passing a permission more than once has no additional effect.
+func FormMemberPermissions(permissions ...common.Permissions) common.Permissions {
+	var total common.Permissions
+	for _, permission := range permissions {
+		total |= permission
 	}
 	return total
 }